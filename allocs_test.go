@@ -0,0 +1,106 @@
+//go:build bouncer_allocs
+
+package bouncer
+
+import (
+	"machine"
+	"testing"
+	"time"
+)
+
+// The allocation tests build on a desktop host, against the machine stub in testdata; run them with
+// testdata/allocs.sh
+
+// fakeClock is moved on by hand, so that presses are timed as the test means them to be
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// allocBouncer returns a bouncer on pin, released & configured with cfg, along with its subscriber and clock
+func allocBouncer(t testing.TB, pin machine.Pin, cfg Config) (*bouncer, chan PressLength, *fakeClock) {
+	c := &fakeClock{now: time.Unix(1, 0)}
+	SetClock(c)
+	pin.Set(true) // released, with InputPullup
+	out := make(chan PressLength, 1)
+	bb, err := New(pin, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bb.Configure(cfg); err != nil {
+		t.Fatal(err)
+	}
+	return bb.(*bouncer), out, c
+}
+
+// press has b's interrupt handler see a press held for d, recognized & delivered by Update
+func press(b *bouncer, c *fakeClock, d time.Duration) {
+	b.pins[0].Set(false)
+	b.emit(false)
+	c.now = c.now.Add(time.Millisecond)
+	Tick()
+	b.Update() // the press is debounced
+	c.now = c.now.Add(d)
+	b.pins[0].Set(true)
+	b.emit(true)
+	Tick()
+	b.Update() // ...and recognized on release
+	c.now = c.now.Add(time.Second)
+}
+
+func TestEdgeAllocs(t *testing.T) {
+	b, _, _ := allocBouncer(t, machine.D0, Config{})
+	if n := testing.AllocsPerRun(100, func() {
+		b.emit(false)
+		<-b.isrChan
+	}); n != 0 {
+		t.Errorf("an edge on isrChan allocates %v times", n)
+	}
+	b, _, _ = allocBouncer(t, machine.D1, Config{Ring: true})
+	if n := testing.AllocsPerRun(100, func() {
+		b.emit(false)
+		b.emit(true)
+		for _, ok := b.isrRing.get(); ok; _, ok = b.isrRing.get() {
+		}
+	}); n != 0 {
+		t.Errorf("an edge through the ring allocates %v times", n)
+	}
+	b, _, _ = allocBouncer(t, machine.D2, Config{EdgeFlag: true})
+	if n := testing.AllocsPerRun(100, func() {
+		b.emit(false)
+		b.edgeFlag.take()
+	}); n != 0 {
+		t.Errorf("a flagged edge allocates %v times", n)
+	}
+}
+
+func TestTickAllocs(t *testing.T) {
+	b, _, c := allocBouncer(t, machine.D3, Config{SuperLoop: true, Ring: true})
+	if n := testing.AllocsPerRun(100, func() {
+		c.now = c.now.Add(20 * time.Millisecond)
+		Tick()
+		b.Update()
+	}); n != 0 {
+		t.Errorf("a tick allocates %v times", n)
+	}
+}
+
+func TestPublishAllocs(t *testing.T) {
+	b, out, c := allocBouncer(t, machine.D4, Config{SuperLoop: true, Ring: true})
+	if n := testing.AllocsPerRun(100, func() {
+		press(b, c, 100*time.Millisecond)
+		if p := <-out; p != ShortPress {
+			t.Fatalf("got %v, want ShortPress", p)
+		}
+	}); n != 0 {
+		t.Errorf("a press allocates %v times", n)
+	}
+}
+
+func BenchmarkPress(bm *testing.B) {
+	b, out, c := allocBouncer(bm, machine.D5, Config{SuperLoop: true, Ring: true})
+	bm.ReportAllocs()
+	for i := 0; i < bm.N; i++ {
+		press(b, c, 100*time.Millisecond)
+		<-out
+	}
+}
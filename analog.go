@@ -0,0 +1,139 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// AnalogInput is a source of 16-bit samples; a configured machine.ADC is one
+type AnalogInput interface {
+	Get() uint16
+}
+
+type analog struct {
+	in             AnalogInput
+	threshold      uint16 // a sample on the far side of this is pressed
+	hysteresis     uint16 // how far back across the threshold a sample must come to be released
+	activeHigh     bool   // pressed reads above the threshold, rather than below it
+	stableTicks    int    // consecutive samples needed on the new side before the state changes
+	shortPress     time.Duration
+	longPress      time.Duration
+	extraLongPress time.Duration
+	tickerCh       chan struct{}        // produced by sendTicks -> consumed by RecognizeAndPublish
+	done           chan struct{}        // closed by Shutdown, ending RecognizeAndPublish
+	stopped        uint32               // set once Shutdown has stopped the device
+	outChans       []chan<- PressLength // receive a PressLength each time the input is released
+	count          int                  // consecutive samples which have disagreed with the debounced state
+	pressed        uint32               // debounced state, set atomically so Pressed can read it
+	btnDown        Instant              // beginning of the current press
+	listening      uint32               // analog inputs are polled, so they always listen for ticks
+}
+
+// Analog treats an ADC channel compared against a threshold as a button, for light-dependent resistors,
+// pressure pads & the like which would otherwise need an external comparator
+type Analog interface {
+	Configure(Config) error
+	RecognizeAndPublish()
+	Pressed() bool
+}
+
+// NewAnalog returns a new Analog (or error) reading in, with the same default durations as New. With the
+// default ActiveLow polarity a sample below threshold is a press, which is released once a sample reaches
+// threshold+hysteresis; with ActiveHigh a sample above threshold is a press, released below threshold-hysteresis
+func NewAnalog(in AnalogInput, threshold, hysteresis uint16, outs ...chan<- PressLength) (Analog, error) {
+	if len(outs) < 1 {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	return &analog{
+		in:             in,
+		threshold:      threshold,
+		hysteresis:     hysteresis,
+		stableTicks:    2,
+		shortPress:     22 * time.Millisecond,
+		longPress:      500 * time.Millisecond,
+		extraLongPress: 1971 * time.Millisecond,
+		tickerCh:       make(chan struct{}, 1),
+		done:           make(chan struct{}),
+		outChans:       outs,
+	}, nil
+}
+
+// Configure validates the config, overrides default durations, and subscribes the input to ticks. Polarity
+// picks which side of the threshold is pressed, and DebounceTicks (if set) how many consecutive samples must
+// agree before the state changes. The ADC itself must already be configured
+func (a *analog) Configure(cfg Config) error {
+	short, long, extraLong := mergeDurations(cfg, a.shortPress, a.longPress, a.extraLongPress)
+	if err := validate(cfg, short, long, extraLong); err != nil {
+		return err
+	}
+	a.activeHigh = cfg.Polarity == ActiveHigh
+	if a.activeHigh && a.hysteresis > a.threshold || !a.activeHigh && uint32(a.threshold)+uint32(a.hysteresis) > 0xffff {
+		return errors.New(ERROR_HYSTERESIS_TOO_WIDE)
+	}
+	if cfg.DebounceTicks > 0 {
+		a.stableTicks = cfg.DebounceTicks
+	}
+	a.shortPress, a.longPress, a.extraLongPress = short, long, extraLong
+	addSysTickConsumer(a.tickerCh, &a.listening)
+	listen(&a.listening, true)
+	devices.add(a)
+	return nil
+}
+
+// Pressed returns the debounced state of the input
+func (a *analog) Pressed() bool {
+	return atomic.LoadUint32(&a.pressed) == 1
+}
+
+// RecognizeAndPublish should be a goroutine; samples the input on each tick, changing state once stableTicks
+// samples in a row have been on the other side of the threshold (less the hysteresis, when releasing), and
+// publishes the press length on release
+func (a *analog) RecognizeAndPublish() {
+	for {
+		select {
+		case <-a.tickerCh:
+		case <-a.done:
+			return
+		}
+		pressed := a.Pressed()
+		if a.crossed(a.in.Get(), pressed) {
+			a.count++
+		} else {
+			a.count = 0
+		}
+		if a.count < a.stableTicks {
+			continue
+		}
+		a.count = 0
+		if !pressed {
+			a.btnDown = clockNow()
+			atomic.StoreUint32(&a.pressed, 1)
+			continue
+		}
+		atomic.StoreUint32(&a.pressed, 0)
+		p := classify(clockSince(a.btnDown), a.shortPress, a.longPress, a.extraLongPress)
+		for _, ch := range a.outChans {
+			ch <- p
+		}
+	}
+}
+
+// crossed reports whether sample v disagrees with the debounced state
+func (a *analog) crossed(v uint16, pressed bool) bool {
+	switch {
+	case a.activeHigh && !pressed:
+		return v > a.threshold
+	case a.activeHigh:
+		return v < a.threshold-a.hysteresis
+	case !pressed:
+		return v < a.threshold
+	default:
+		return v >= a.threshold+a.hysteresis
+	}
+}
+
+// stop ends RecognizeAndPublish, for Shutdown
+func (a *analog) stop() {
+	halt(a.tickerCh, a.done, &a.listening, &a.stopped)
+}
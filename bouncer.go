@@ -1,18 +1,51 @@
 // bouncer is an input recognition package that recognizes button-presses
 // of various lengths, notifies an arbitrary number of subscribers, and implements
 // debouncing using the systick.
+//
+// Everything a bouncer needs is allocated by New and Configure; the interrupt handler, tick relay,
+// recognizer and publishing allocate nothing per press or per tick (see testdata/allocs.sh). An
+// OverrunError sent to SubscribeErrors is the exception.
 package bouncer
 
 import (
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"machine"
 )
 
 const (
-	ERROR_INVALID_PRESSLENGTH = "PressLength not understood"
-	ERROR_NO_OUTPUT_CHANNELS  = "New bouncer wasn't given any output channels"
+	ERROR_INVALID_PRESSLENGTH   = "PressLength not understood"
+	ERROR_NO_OUTPUT_CHANNELS    = "New bouncer wasn't given any output channels"
+	ERROR_WAKE_UNSUPPORTED      = "Pin can't be a wake source on this target"
+	ERROR_NO_PINS               = "New bouncer wasn't given any pins"
+	ERROR_TOO_MANY_PINS         = "New DIP bank was given more than 32 pins"
+	ERROR_NOT_A_BOUNCER         = "Bouncer wasn't made by this package"
+	ERROR_INVALID_TICK_RATE     = "Tick rate must be greater than zero"
+	ERROR_INVALID_FRAME         = "Event frame is malformed"
+	ERROR_UNKNOWN_WIRE_VERSION  = "Event frame has an unknown wire format version"
+	ERROR_FILTER_UNSUPPORTED    = "Hardware filtering isn't supported on this target"
+	ERROR_FILTER_EXHAUSTED      = "No hardware filter state machines left"
+	ERROR_FILTER_TOO_LONG       = "Hardware filter duration is too long"
+	ERROR_PORT_FULL             = "Port batching supports at most 32 pins"
+	ERROR_INTERRUPT_UNAVAILABLE = "Pin interrupt unavailable (are the target's interrupt channels exhausted?)"
+	ERROR_SENSE_UNSUPPORTED     = "Pin SENSE fallback isn't supported on this target or pin"
+	ERROR_LINE_CONFLICT         = "Pin shares an external interrupt line with another configured pin"
+	ERROR_NEGATIVE_DURATION     = "Config durations & tick counts can't be negative"
+	ERROR_THRESHOLD_ORDER       = "Config thresholds must be strictly increasing: Short < Long < ExtraLong (< Max)"
+	ERROR_TICK_THRESHOLD_ORDER  = "Config tick thresholds must be strictly increasing: ShortTicks < LongTicks < ExtraLongTicks"
+	ERROR_NOT_CONFIGURED        = "Bouncer hasn't been configured"
+	ERROR_NO_STEPS              = "Combo has no steps"
+	ERROR_NO_PRESSES            = "Calibration needs at least one press"
+	ERROR_HYSTERESIS_TOO_WIDE   = "Analog threshold plus or minus hysteresis is out of range"
+	ERROR_TICK_RATE_TOO_SLOW    = "Tick rate is too slow to see the configured debounce & shortest press (see RequiredTickRate)"
+	ERROR_NO_BUTTONS            = "Recovery wasn't given any buttons"
+	ERROR_NO_RECOVERY_ACTION    = "Recovery needs an OnFire callback"
+	ERROR_FAULT_ACTIVE          = "Fault can't be reset while the e-stop is still pressed"
+	ERROR_GESTURES_COMPILED_OUT = "TapHold & DoubleTapHold are compiled out by the bouncer_nogestures tag"
+	ERROR_SUPERLOOP_NEEDS_RING  = "SuperLoop needs Ring or EdgeFlag, as the default edge channel can block in the interrupt"
 )
 
 type PressLength uint8
@@ -22,29 +55,290 @@ const (
 	ShortPress
 	LongPress
 	ExtraLongPress
+	DoubleClick       // two ShortPresses within Config.ClickWindow
+	On                // a maintained switch settled closed (ToggleMode)
+	Off               // a maintained switch settled open (ToggleMode)
+	Tap               // a debounced press shorter than Short, published instead of Bounce when Config.Taps is set
+	StuckFault        // the button has been held longer than Config.StuckAfter
+	Closed            // a reed switch or magnetic contact settled closed (ReedMode)
+	Open              // a reed switch or magnetic contact settled open (ReedMode)
+	Vibration         // a vibration or tilt sensor produced VibrationEdges edges within VibrationWindow (VibrationMode)
+	TapHold           // a ShortPress followed within Config.ClickWindow by a press held to Long, when Config.TapHold is set
+	DoubleTapHold     // two ShortPresses, each within Config.ClickWindow, then a press held to Long (Config.DoubleTapHold)
+	SwipeNext         // button A then button B pressed within a swipe's window; see BindSwipe
+	SwipePrevious     // button B then button A pressed within a swipe's window
+	LongPressCanceled // released after LongPress was announced but before ExtraLong (Config.LeadingEdge)
+	ComboMatched      // a registered Combo was completed; the Event's Name is the Combo's
+	Degraded          // the switch's bounce, averaged over recent presses, has passed Config.DegradedBounce
+	StateOn           // a ShortPress latched the state on (LatchMode)
+	StateOff          // a ShortPress latched the state off (LatchMode)
+	WireFault         // a NormallyClosed circuit has been open for longer than Config.StuckAfter; the wire may be broken
+	Held              // the button is down right now: a Heartbeat, or Replayed to a new bus subscriber
+	Released          // the button is up right now: a Heartbeat
+	Idle              // no press for IdleAfter
+	Active            // the first press after Idle
+	Activate          // both buttons of a TwoHand control are held, pressed within its window
+	Deactivate        // a TwoHand control's button was let go
+	EStop             // an EStopMode button was pressed (or its circuit opened); published at once and latched
 )
 
-type sysTickSubscriber struct {
-	channel chan struct{}
-}
+// Mode selects how a bouncer interprets its pin
+type Mode uint8
+
+const (
+	PressMode     Mode = iota // momentary button; publishes press lengths on release
+	ToggleMode                // maintained toggle or rocker switch; publishes On & Off as the switch settles
+	ReedMode                  // reed switch or magnetic contact; publishes Closed & Open once a state has held for MinState
+	VibrationMode             // SW-420 or ball-tilt sensor; publishes Vibration when edges come thick and fast
+	LatchMode                 // momentary button as a power toggle; each ShortPress flips the state, publishing StateOn or StateOff
+	// EStopMode is for normally-closed emergency-stop buttons (use Polarity NormallyClosed): the circuit opening
+	// publishes EStop immediately and latches a fault, and nothing more is published until ResetFault
+	EStopMode
+)
+
+// Polarity is the pin level of a pressed button
+type Polarity uint8
+
+const (
+	ActiveLow  Polarity = iota // pressed pulls the pin to ground; the pin is set to InputPullup
+	ActiveHigh                 // pressed pulls the pin high; the pin is set to InputPulldown
+	// NormallyClosed is for NC switches to ground, as safety switches almost always are: the circuit is closed
+	// at rest and a press opens it, so the pin is set to InputPullup and reads high when pressed.
+	// A broken wire looks like a press that never ends, so a debounced open still open after StuckAfter (5s by
+	// default) publishes WireFault, and isn't published as a press when the circuit closes again. A blip
+	// shorter than the debounce is dropped rather than timed, so it can't raise a WireFault
+	NormallyClosed
+)
+
+// EdgeScheme selects which pin edges raise an interrupt
+type EdgeScheme uint8
+
+const (
+	BothEdges    EdgeScheme = iota // interrupt on both edges
+	FallingEdges                   // interrupt only as the pin falls; rising edges are found by polling on ticks
+	RisingEdges                    // interrupt only as the pin rises; falling edges are found by polling on ticks
+)
+
+// Combine is how the pins of a multi-pin bouncer combine into one logical button
+type Combine uint8
+
+const (
+	AnyDown Combine = iota // the button is down while any pin is (OR)
+	AllDown                // the button is down only while every pin is, e.g. "lid closed AND button pressed" (AND)
+)
+
+var (
+	relayMu   sync.Mutex
+	relayStop = make(chan struct{}) // closed by StopRelay to stop Debounce; read with currentRelayStop
+)
 
-var sysTickSubcribers []sysTickSubscriber
+var relayTicks uint32 // every tick relayed, set atomically; stamped on Events
+
+// Disabled, as a Config's Long or ExtraLong, switches that tier off: it's never recognized or published,
+// and presses which would have reached it are recognized as the highest tier still enabled
+const Disabled time.Duration = -1
 
 type Config struct {
 	Short     time.Duration
 	Long      time.Duration
 	ExtraLong time.Duration
+	Ring      bool // pass edges from the pin interrupt through a lock-free ring buffer instead of isrChan
+	// EdgeFlag has the pin interrupt only latch the latest level & time behind an atomic flag, picked up on each
+	// tick, for targets (AVR, some RISC-V) where no channel operation is safe in an interrupt. Overrides Ring
+	EdgeFlag bool
+	// ClickWindow, when nonzero, withholds each ShortPress for this long after release;
+	// a second ShortPress within the window publishes a single DoubleClick instead of two ShortPresses
+	ClickWindow time.Duration
+	Mode        Mode
+	Name        string // identifies the bouncer in Events
+	Bus         bool   // also publish Events on the package-level event bus
+	// DebounceTicks is how many systicks must elapse between 'down' and 'up' for a press to count;
+	// zero keeps the default of 1
+	DebounceTicks int
+	// ShortTicks, LongTicks & ExtraLongTicks, when ShortTicks is nonzero, replace the durations above:
+	// presses are recognized by the number of systicks elapsed rather than by time.Now
+	ShortTicks     int
+	LongTicks      int
+	ExtraLongTicks int
+	// LED, when set, is driven as feedback: lit while the button is held, then blinked LEDBlinks[p] times
+	// when PressLength p is published. A nil LEDBlinks blinks once, twice & three times for short, long & extra long
+	LED       *machine.Pin
+	LEDBlinks map[PressLength]int
+	Feedback  Feedback // called directly by the recognizer for immediate haptic or audible feedback
+	Taps      bool     // publish presses shorter than Short as Tap rather than Bounce
+	// StuckAfter, when nonzero, publishes StuckFault once a button has been held this long; its eventual
+	// release is then not published as a press. With StuckIdle, a stuck bouncer also stops taking ticks
+	StuckAfter time.Duration
+	StuckIdle  bool
+	// HardwareFilter, when nonzero, has the hardware reject glitches shorter than this before edges reach
+	// the recognizer, replacing the pin interrupt (RP2040 only, using PIO1)
+	HardwareFilter time.Duration
+	// Port runs every bouncer configured with it through one interrupt callback (each pin still has its own
+	// interrupt), which snapshots all their pins and passes edges to whichever bouncers' pins changed
+	Port bool
+	// SenseFallback, where a pin interrupt can't be had (nRF52 has only 8 GPIOTE channels), falls back to the
+	// pin's SENSE mechanism, polled each tick, instead of failing Configure
+	SenseFallback bool
+	Polarity      Polarity
+	// MinState is how long a reed switch must hold a new state before it's published, rejecting magnet flutter;
+	// zero keeps the default of 250ms (ReedMode only)
+	MinState time.Duration
+	// VibrationEdges edges within VibrationWindow publish Vibration; zeros keep the defaults of 10 edges in 1s
+	// (VibrationMode only)
+	VibrationEdges  int
+	VibrationWindow time.Duration
+	// Shared has the bouncer recognized by the package's single RecognizeShared goroutine
+	// instead of its own RecognizeAndPublish, saving a goroutine stack per button
+	Shared bool
+	// Edges, for pins without dual-edge interrupts or to halve the interrupt load, registers only one edge;
+	// the other is synthesized by polling the pin on ticks while it's in the state that edge would leave
+	Edges EdgeScheme
+	// LeadingEdge publishes ShortPress as soon as a press is debounced, then LongPress & ExtraLongPress as it's
+	// held past those thresholds, rather than recognizing on release. On release, nothing more is published
+	// unless LongPress had been and ExtraLongPress hadn't, which publishes LongPressCanceled
+	// (PressMode only; ClickWindow and Taps don't apply)
+	LeadingEdge bool
+	// Cooldown ignores presses released within this long of the last one, so a double-pumped button acts once
+	Cooldown time.Duration
+	// Max, when nonzero, is the longest press accepted: one released after longer is published as StuckFault
+	// rather than ExtraLongPress, so a button pinned down for ten minutes doesn't trigger a factory reset
+	Max time.Duration
+	// TapHold, with ClickWindow, publishes TapHold as soon as a press following a ShortPress within the window
+	// has been held to Long (for drag operations); neither the tap nor the hold's release is then published
+	TapHold bool
+	// DoubleTapHold, with ClickWindow, likewise publishes DoubleTapHold for two taps then a hold; the second tap
+	// delays DoubleClick until the window has passed without a hold
+	DoubleTapHold bool
+	// SkipPinConfigure leaves the pin mode alone, for pins already set up (with special drive or pull settings)
+	// by a board-support package; Polarity must still describe how the pins are wired
+	SkipPinConfigure bool
+	// PollFallback, where a pin interrupt can't be had, falls back to sampling the pins on every tick instead of
+	// failing Configure (after SenseFallback, if that's set too). See InterruptBacked
+	PollFallback bool
+	// GlitchFilter, when nonzero, discards any pair of opposite edges closer together than this (a few hundred
+	// microseconds, say) before they reach the recognizer, rejecting EMI on long button cables. Each edge is
+	// held back until the filter has passed it, which can delay it by up to a tick
+	GlitchFilter time.Duration
+	// DegradedBounce, when nonzero, publishes Degraded once the bounce at press, averaged over the last few presses,
+	// trends past this; a worn switch bounces for longer. It's published again only after the average recovers
+	DegradedBounce time.Duration
+	// TraceEdges, when nonzero, keeps the last this many raw edges the recognizer saw, for DumpTrace
+	TraceEdges int
+	// Combine is how a multi-pin bouncer's pins make up the button; they're combined before debouncing,
+	// so the combination is debounced as a unit
+	Combine Combine
+	// TickGap is how long an interval between ticks counts as a gap in TickStats; zero means twice the mean interval
+	TickGap time.Duration
+	// TickEvery, when above 1, relays only every Nth tick to the bouncer, so a slow switch sharing a fast relay
+	// wakes less often. Tick counts (DebounceTicks, ShortTicks...) count the ticks the bouncer actually receives
+	TickEvery int
+	// Heartbeat, when nonzero, publishes the debounced state as Held or Released this often, changed or not,
+	// for consumers such as a PLC-style control loop which want a polled feed rather than events
+	Heartbeat time.Duration
+	// IdleAfter, when nonzero, publishes Idle once a momentary button has gone this long without a press, and
+	// Active as soon as the next press is debounced, for screensaver & backlight timeouts
+	IdleAfter time.Duration
+	// SuperLoop declares that the bouncer is driven by Update from a main loop rather than by RecognizeAndPublish.
+	// With nothing else running, an interrupt blocked on the default edge channel would never be unblocked, so
+	// it requires Ring or EdgeFlag
+	SuperLoop bool
 }
 
 type bouncer struct {
-	pin              *machine.Pin
+	pins             []machine.Pin // OR'd together; the button is 'down' while any pin is
 	debounceInterval time.Duration
-	shortPress       time.Duration
-	longPress        time.Duration
-	extraLongPress   time.Duration
-	tickerCh         chan struct{}      // produced by sendTicks (relaying systick_handler ticks) -> consumed by RecognizeAndPublish (listening for ticks)
-	isrChan          chan bool          // produced by the pin interrupt handler -> consumed by RecognizeAndPublish
-	outChans         []chan PressLength // various channels produced by RecognizeAndPublish -> consumed by subscribers of this bouncer's events
+	press            atomic.Value         // pressThresholds; see thresholds
+	tickerCh         chan struct{}        // produced by sendTicks (relaying systick_handler ticks) -> consumed by RecognizeAndPublish (listening for ticks)
+	isrChan          chan Edge            // produced by the pin interrupt handler -> consumed by RecognizeAndPublish
+	isrRing          *ring                // replaces isrChan when Config.Ring is set; drained by RecognizeAndPublish on each tick
+	edgeFlag         *edgeFlag            // replaces isrChan when Config.EdgeFlag is set; taken by the recognizer on each tick
+	outChans         []chan<- PressLength // various channels produced by RecognizeAndPublish -> consumed by subscribers of this bouncer's events
+	ticks            int                  // ticks will begin to increment when a button 'down' is registered
+	btnDown          Instant              // btnDown is the beginning time of a button press event
+	clickWindow      time.Duration        // how long a ShortPress is withheld awaiting a second click; zero disables
+	clicks           int                  // ShortPresses being withheld
+	clickAt          Instant              // release time of the latest withheld ShortPress
+	rearm            uint32               // set atomically by Wake; the recognizer resamples the pin on the next tick
+	listening        uint32               // set atomically while the recognizer needs ticks; see setListening
+	mode             Mode
+	switchUp         uint32               // debounced state in ToggleMode, set atomically so State can read it
+	latched          uint32               // the latched state in LatchMode, set atomically so State can read it
+	held             uint32               // set atomically for the duration of a press sequence; read by its swipe partner
+	modified         uint32               // set atomically when a bouncer publishes modified by this one; suppresses this one's own press
+	modifier         *bouncer             // while modifier is held, events go to altChans instead of outChans
+	altChans         []chan<- PressLength // receive this bouncer's events while modifier is held
+	name             string
+	bus              bool                 // publish to the package-level event bus too
+	priorityChans    []chan<- PressLength // sent to synchronously, in order, before any other subscriber
+	debounceTicks    int                  // ticks which must elapse between 'down' and 'up'
+	shortTicks       int                  // tick thresholds; used instead of durations when shortTicks > 0
+	longTicks        int
+	extraLongTicks   int
+	led              machine.Pin
+	hasLED           bool
+	ledBlinks        map[PressLength]int
+	ledSteps         int     // half-periods of blinking remaining
+	ledNext          Instant // when the next half-period begins
+	feedback         Feedback
+	taps             bool
+	stuckAfter       time.Duration
+	stuckIdle        bool
+	stuck            bool                          // StuckFault has been published for the current press
+	filteredDown     uint32                        // pins reported 'down' by the hardware filter, one bit each; only touched in its interrupt
+	filterSM         int                           // first of the bouncer's hardware filter state machines, plus one; zero if it has none
+	sensePins        []machine.Pin                 // pins without an interrupt, watched through SENSE & polled on each tick
+	activeHigh       bool                          // a pressed pin reads high
+	combine          Combine                       // how the pins combine into one button
+	normallyClosed   bool                          // Polarity NormallyClosed: a stuck press is a broken wire
+	minState         time.Duration                 // a maintained switch must hold a new state this long before it's published
+	vibrationEdges   int                           // edge count which counts as vibration
+	vibrationWindow  time.Duration                 // period over which edges are counted
+	edges            int                           // edges counted in the current window
+	emit             func(up bool)                 // feeds an edge to the recognizer the same way the interrupt handler does
+	recorder         *Recorder                     // captures every edge the recognizer sees
+	trace            *trace                        // the last Config.TraceEdges edges
+	handlers         [len(pressLengthNames)]func() // called by the dispatcher, indexed by PressLength
+	eventChans       []chan<- Event                // receive whole Events; see NewGroup
+	edgeScheme       EdgeScheme                    // which edges interrupt; the other is polled for
+	levelUp          bool                          // the debounced side's last seen state, for synthesizing the polled edge
+	polled           bool                          // a pin interrupt couldn't be had, so the pins are sampled every tick
+	glitch           time.Duration                 // see Config.GlitchFilter
+	glitchPending    Edge                          // the edge being held back by the glitch filter
+	glitchHeld       bool
+	bounceEnd        Instant // time of the last bounce edge of the press in progress
+	bounces          uint8   // bounce edges suppressed during the press in progress, or the last press
+	degradedBounce   time.Duration
+	wear             [wearWindow]time.Duration // bounce of recent presses, for spotting wear
+	wearAt           int                       // next slot of wear to fill
+	wearN            int                       // slots of wear filled
+	degraded         bool                      // Degraded has been published, and the average hasn't recovered since
+	leading          bool                      // publish on the debounced press-down edge, upgrading while held
+	leadTier         PressLength               // the highest tier published so far during this press
+	gestures         []gesture                 // the compound gestures recognized
+	gestured         bool                      // this press has completed a compound gesture, so its release isn't published
+	swipe            *swipe                    // correlates this bouncer's presses with another's; see BindSwipe
+	swiped           uint32                    // set atomically when the press in progress was part of a swipe
+	waiting          uint32                    // set atomically while WaitFor is blocked
+	waitCh           chan PressLength
+	counts           PressCounts // events published, updated atomically
+	cooldown         time.Duration
+	coolUntil        Instant       // presses released before this are ignored
+	max              time.Duration // longer presses are StuckFaults
+	done             chan struct{} // closed by Close to stop RecognizeAndPublish
+	closed           uint32        // set atomically by the first Close
+	recMu            sync.Mutex    // held by the recognizer while it handles an edge or tick, and by Configure & Close while they replace what it reads
+	lastMu           sync.Mutex    // guards last & hasLast
+	last             Event         // the most recent event published, for Topic.Replay
+	hasLast          bool
+	heartbeat        time.Duration // see Config.Heartbeat
+	beatAt           Instant       // when the last heartbeat was due
+	idleAfter        time.Duration // see Config.IdleAfter
+	activeAt         Instant       // the last time the button was down
+	inactive         bool          // Idle has been published, and Active hasn't since
+	faulted          uint32        // EStopMode's latched fault, set atomically
+	down             uint32        // the press in progress has been debounced, set atomically so other goroutines can read it
+	tickMeter        tickMeter     // regularity of the ticks received, for TickStats
+	tickEvery        int           // see Config.TickEvery
 }
 
 type Bouncer interface {
@@ -52,155 +346,670 @@ type Bouncer interface {
 	RecognizeAndPublish()
 	State() bool
 	Duration(PressLength) time.Duration
+	ConfigureWake() error
+	Wake()
+	SubscribePriority(chan<- PressLength)
+	Record(*Recorder)
+	Close()
+	Update()
+	WaitForPress(timeout time.Duration) (PressLength, bool)
+	WaitFor(timeout time.Duration, lengths ...PressLength) (PressLength, bool)
+	Counts() PressCounts
+	ResetCounts()
+	InterruptBacked() bool
+	Calibrate(presses int) (Calibration, error)
+	SelfTest(loopback machine.Pin) SelfTestResult
+	DumpTrace() []Edge
+	Handle(PressLength, func())
+	OnShort(func())
+	OnLong(func())
+	OnExtraLong(func())
+	TickStats() TickStats
+	Faulted() bool
+	ResetFault() error
 }
 
 // New returns a new Bouncer (or error) with the given pin, name & channels, with default durations for
 // shortPress, longPress, extraLongPress
-func New(p machine.Pin, outs ...chan PressLength) (Bouncer, error) {
+func New(p machine.Pin, outs ...chan<- PressLength) (Bouncer, error) {
+	return NewMulti([]machine.Pin{p}, outs...)
+}
+
+// NewMulti returns a new Bouncer (or error) for one logical button wired to several pins, such as duplicate
+// trigger contacts; the button is 'down' while any of the pins is, and edges from every pin feed the same recognizer
+func NewMulti(pins []machine.Pin, outs ...chan<- PressLength) (Bouncer, error) {
+	if len(pins) < 1 {
+		return nil, errors.New(ERROR_NO_PINS)
+	}
 	if len(outs) < 1 {
 		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
 	}
-	outChans := make([]chan PressLength, 0)
+	return newBouncer(pins, outs), nil
+}
+
+// newBouncer makes a bouncer with default durations, whether or not it has any channels
+func newBouncer(pins []machine.Pin, outs []chan<- PressLength) *bouncer {
+	outChans := make([]chan<- PressLength, 0)
 	for i := range outs {
 		outChans = append(outChans, outs[i])
 	}
-	return &bouncer{
-		pin:            &p,
-		shortPress:     22 * time.Millisecond,
-		longPress:      500 * time.Millisecond,
-		extraLongPress: 1971 * time.Millisecond,
-		tickerCh:       make(chan struct{}, 1),
-		isrChan:        make(chan bool, 1),
-		outChans:       outChans,
-	}, nil
+	b := &bouncer{
+		pins:          append([]machine.Pin(nil), pins...),
+		debounceTicks: 1,
+		tickerCh:      make(chan struct{}, 1),
+		isrChan:       make(chan Edge, 1),
+		outChans:      outChans,
+		done:          make(chan struct{}),
+		waitCh:        make(chan PressLength, 1),
+	}
+	b.press.Store(pressThresholds{short: 22 * time.Millisecond, long: 500 * time.Millisecond, extraLong: 1971 * time.Millisecond})
+	return b
 }
 
-// Configure sets the pin mode to InputPullup, assigns interrupt handler, overrides default durations
+// Configure validates the config, sets the pin mode to InputPullup (or InputPulldown if ActiveHigh), assigns interrupt handler, and overrides
+// default durations; zero durations keep their defaults. Configuring again replaces the interrupt handler & tick
+// subscription rather than adding to them, and swaps the press thresholds in one go. Configuring a running bouncer
+// waits for its recognizer to finish the edge or tick in hand, so the new config applies between events
 func (b *bouncer) Configure(cfg Config) error {
-	b.pin.Configure(machine.PinConfig{Mode: machine.PinInputPullup})
-	err := b.pin.SetInterrupt(machine.PinFalling|machine.PinRising, func(machine.Pin) {
-		b.isrChan <- b.pin.Get()
-	})
+	b.recMu.Lock()
+	err := b.configure(cfg)
+	b.recMu.Unlock()
+	if err == nil {
+		return nil
+	}
+	ce, ok := err.(*ConfigError)
+	if !ok {
+		ce = &ConfigError{Pin: b.pins[0], Err: err}
+	}
+	ce.Name = cfg.Name
+	return ce
+}
+
+// configure does the work of Configure, returning a ConfigError only where it knows which pin is at fault
+func (b *bouncer) configure(cfg Config) error {
+	t := b.thresholds()
+	short, long, extraLong := mergeDurations(cfg, t.short, t.long, t.extraLong)
+	if err := validate(cfg, short, long, extraLong); err != nil {
+		return err
+	}
+	gestures, err := enabledGestures(cfg)
 	if err != nil {
 		return err
 	}
-	if b.shortPress > 0 {
-		b.shortPress = cfg.Short
+	debounceTicks := b.debounceTicks
+	if cfg.DebounceTicks > 0 {
+		debounceTicks = cfg.DebounceTicks
+	}
+	hz := tickRate()
+	if cfg.TickEvery > 1 {
+		hz /= uint32(cfg.TickEvery)
+	}
+	if hz > 0 && hz < requiredTickRate(cfg, short, debounceTicks) {
+		return errors.New(ERROR_TICK_RATE_TOO_SLOW)
+	}
+	if b.configured() { // configuring again: replace what was attached last time
+		b.detach()
+	}
+	emit := func(up bool) {
+		b.isrChan <- Edge{Up: up, Time: clockNow()}
 	}
-	if b.longPress > 0 {
-		b.longPress = cfg.Long
+	if cfg.Ring {
+		// with several pins there are several producers, which is still safe as long as
+		// the pins' interrupts can't preempt one another
+		r := &ring{} // the handler keeps its own reference, as a re-Configure may swap b.isrRing
+		b.isrRing = r
+		emit = func(up bool) {
+			if !r.put(Edge{Up: up, Time: clockNow()}) { // a full ring drops the edge
+				atomic.AddUint32(&droppedEdges, 1)
+			}
+		}
 	}
-	if b.extraLongPress > 0 {
-		b.extraLongPress = cfg.ExtraLong
+	if cfg.EdgeFlag {
+		b.isrRing = nil
+		f := &edgeFlag{}
+		b.edgeFlag = f
+		emit = func(up bool) {
+			f.set(up, clockNow())
+		}
+	}
+	if cfg.Shared && !cfg.EdgeFlag { // flagged edges are picked up on the ticks which nudge shared recognition anyway
+		edge := emit
+		emit = func(up bool) {
+			edge(up)
+			nudgeShared()
+		}
+	}
+	b.emit = emit
+	b.activeHigh = cfg.Polarity == ActiveHigh || cfg.Polarity == NormallyClosed
+	b.combine = cfg.Combine
+	b.tickMeter.gap = cfg.TickGap
+	b.heartbeat, b.beatAt = cfg.Heartbeat, never
+	b.idleAfter, b.activeAt, b.inactive = cfg.IdleAfter, clockNow(), false
+	mode := machine.PinInputPullup
+	if cfg.Polarity == ActiveHigh {
+		mode = machine.PinInputPulldown
+	}
+	for _, p := range b.pins {
+		if !cfg.SkipPinConfigure {
+			p.Configure(machine.PinConfig{Mode: mode})
+		}
+	}
+	if err := b.attachCapture(cfg, emit); err != nil {
+		return err
+	}
+	b.press.Store(pressThresholds{short: short, long: long, extraLong: extraLong})
+	b.clickWindow = cfg.ClickWindow
+	if cfg.DebounceTicks > 0 {
+		b.debounceTicks = cfg.DebounceTicks
+	}
+	b.shortTicks = cfg.ShortTicks
+	b.longTicks = cfg.LongTicks
+	b.extraLongTicks = cfg.ExtraLongTicks
+	b.mode = cfg.Mode
+	b.name = cfg.Name
+	b.bus = cfg.Bus
+	b.minState = cfg.MinState
+	if b.mode == ReedMode && b.minState == 0 {
+		b.minState = 250 * time.Millisecond
+	}
+	b.levelUp = b.get()
+	if b.mode == EStopMode && !b.levelUp { // already pressed at startup: start out faulted
+		atomic.StoreUint32(&b.faulted, 1)
+	}
+	if b.maintained() {
+		b.setSwitchUp(b.get()) // adopt the switch's position at startup without publishing it
+	}
+	b.vibrationEdges, b.vibrationWindow = 10, time.Second
+	if cfg.VibrationEdges > 0 {
+		b.vibrationEdges = cfg.VibrationEdges
+	}
+	if cfg.VibrationWindow > 0 {
+		b.vibrationWindow = cfg.VibrationWindow
+	}
+	b.configureLED(cfg)
+	b.feedback = cfg.Feedback
+	b.taps = cfg.Taps
+	b.stuckAfter = cfg.StuckAfter
+	b.normallyClosed = cfg.Polarity == NormallyClosed
+	if b.normallyClosed && b.stuckAfter == 0 {
+		b.stuckAfter = 5 * time.Second
+	}
+	b.stuckIdle = cfg.StuckIdle
+	b.leading = cfg.LeadingEdge
+	b.cooldown, b.coolUntil = cfg.Cooldown, never
+	b.max = cfg.Max
+	b.glitch = cfg.GlitchFilter
+	b.degradedBounce = cfg.DegradedBounce
+	b.trace = nil
+	if cfg.TraceEdges > 0 {
+		b.trace = &trace{edges: make([]Edge, cfg.TraceEdges)}
+	}
+	b.gestures = gestures
+	tickSubscribers.add(&sysTickSubscriber{channel: b.tickerCh, listening: &b.listening, every: cfg.TickEvery})
+	b.tickEvery = cfg.TickEvery
+	b.setListening(b.needsTicks())
+	registered.add(b)
+	if cfg.Shared {
+		sharedBouncers.add(b)
+	} else {
+		sharedBouncers.remove(b)
 	}
-	addSysTickConsumer(b.tickerCh)
 	return nil
 }
 
-// State returns an on-demand measurement of the bouncer's pin
+// State returns an on-demand measurement of the bouncer's pin; in ToggleMode & ReedMode it returns the debounced
+// position of the switch instead. Either way true means 'up' (open, with InputPullup). In LatchMode it returns
+// the latched state, true meaning on
 func (b *bouncer) State() bool {
-	return b.pin.Get()
+	if b.maintained() {
+		return atomic.LoadUint32(&b.switchUp) == 1
+	}
+	if b.mode == LatchMode {
+		return atomic.LoadUint32(&b.latched) == 1
+	}
+	return b.get()
+}
+
+// InterruptBacked reports whether the bouncer's edges arrive by interrupt, or false if it's fallen back
+// to polling its pins (PollFallback) or their SENSE state (SenseFallback) on ticks
+func (b *bouncer) InterruptBacked() bool {
+	return !b.polled && len(b.sensePins) == 0
+}
+
+// get returns true ('up') only if every one of the bouncer's pins is up (released); with AllDown,
+// true unless every pin is down
+func (b *bouncer) get() bool {
+	if b.combine == AllDown {
+		for _, p := range b.pins {
+			if p.Get() != b.activeHigh {
+				return true
+			}
+		}
+		return false
+	}
+	for _, p := range b.pins {
+		if p.Get() == b.activeHigh {
+			return false
+		}
+	}
+	return true
 }
 
 // RecognizeAndPublish should be a goroutine; reads pin state & sample time from channel,
 // awaits completion of a buttonDown -> buttonUp sequence, recognizes press length,
 // publishes the recognized press event to the button's output channel(s)
 func (b *bouncer) RecognizeAndPublish() {
-	ticks := 0                  // ticks will begin to increment when a button 'down' is registered
-	btnDown := time.Time{}      // btnDown is the beginning time of a button press event
-	dur := btnDown.Sub(btnDown) // initial duration zero
+	startDispatcher()
 	for {
 		select {
 		case <-b.tickerCh:
-			if ticks == 0 { // we aren't listening
-				btnDown = time.Time{} // ensure this is empty because occasionally it isn't
-				continue
-			} else {
-				ticks += 1
-			}
-		case up := <-b.isrChan:
-			switch up {
-			case true: // button is 'up'
-				if ticks == 0 { // if we were awaiting a new bounce sequence to begin
-					continue // ignore 'up' signal & reset the loop
-				} else { // if we were awaiting the conclusion of a bounce sequence
-					if ticks >= 2 { // if the interval between down & up is greater than systick interval
-						dur = time.Now().Sub(btnDown) // calculate sequence duration
-						ticks = 0                     // stop & reset ticks + look for new bounce sequence
-						btnDown = time.Time{}         // reset button down time
-						// Recognize & publish to channel(s)
-						b.publish(b.recognize(dur))
-					} // or ignore & await next buttonUp if debounce interval was not exceeded
+			b.recMu.Lock()
+			start := clockNow()
+			b.onTick()
+			b.checkOverrun(start, true)
+		case e := <-b.isrChan:
+			b.recMu.Lock()
+			start := clockNow()
+			b.handleEdge(e)
+			b.checkOverrun(start, false)
+		case <-b.done:
+			return
+		}
+		b.setListening(b.needsTicks())
+		b.recMu.Unlock()
+	}
+}
+
+// onTick picks up edges which don't arrive on isrChan, then handles the tick
+func (b *bouncer) onTick() {
+	b.tickMeter.tick(clockNow())
+	if b.isrRing != nil { // pick up any edges the interrupt handler left in the ring
+		for e, ok := b.isrRing.get(); ok; e, ok = b.isrRing.get() {
+			b.handleEdge(e)
+		}
+	}
+	if b.edgeFlag != nil {
+		if e, ok := b.edgeFlag.take(); ok {
+			b.handleEdge(e)
+		}
+	}
+	if len(b.sensePins) > 0 && pollSense(b.sensePins) { // a pin without an interrupt has changed
+		b.handleEdge(Edge{Up: b.get(), Time: clockNow()})
+	}
+	if b.edgeScheme != BothEdges || b.polled {
+		if up := b.get(); up != b.levelUp { // an edge without an interrupt
+			b.handleEdge(Edge{Up: up, Time: clockNow()})
+		}
+	}
+	if b.glitchHeld && clockSince(b.glitchPending.Time) >= b.glitch { // no opposite edge came to cancel it
+		b.releaseGlitch()
+	}
+	b.handleTick()
+	b.heartbeatTick()
+	b.inactivityTick()
+}
+
+// handleTick counts a systick if a bounce sequence is underway
+func (b *bouncer) handleTick() {
+	if b.maintained() {
+		b.handleToggleTick()
+		return
+	}
+	if b.mode == VibrationMode {
+		b.handleVibrationTick()
+		return
+	}
+	b.ledTick()
+	b.cooling(clockNow())
+	if atomic.SwapUint32(&b.rearm, 0) == 1 && b.ticks == 0 && !b.get() { // woke up with the button already down
+		b.handleEdge(Edge{Up: false, Time: clockNow()})
+	}
+	if b.clicks > 0 && b.ticks == 0 && clockSince(b.clickAt) >= b.clickWindow { // no further click came
+		b.flushClicks()
+	}
+	if b.ticks == 0 { // we aren't listening
+		b.btnDown = never // ensure this is empty because occasionally it isn't
+		return
+	}
+	b.ticks += 1
+	if b.ticks > b.debounceTicks && atomic.LoadUint32(&b.down) == 0 && b.get() { // a glitch which never debounced
+		b.abandon()
+		return
+	}
+	if b.ticks > b.debounceTicks && atomic.LoadUint32(&b.down) == 0 && !b.get() { // the press has just been debounced
+		atomic.StoreUint32(&b.down, 1)
+		if b.swipe != nil {
+			b.swipe.pressed(b, b.btnDown)
+		}
+	}
+	if b.leading {
+		b.leadTick()
+	}
+	if len(b.gestures) > 0 {
+		b.gestureTick()
+	}
+	if b.stuckAfter > 0 && !b.stuck && atomic.LoadUint32(&b.down) == 1 && !b.get() && clockSince(b.btnDown) >= b.stuckAfter {
+		b.stuck = true
+		if b.normallyClosed {
+			b.publish(WireFault)
+		} else {
+			b.publish(StuckFault)
+		}
+	}
+}
+
+// abandon drops a sequence which never became a debounced press, such as a glitch shorter than a tick whose
+// release came before the debounce was over; left running, it would swallow the next press & count toward StuckAfter
+func (b *bouncer) abandon() {
+	b.ticks = 0
+	b.btnDown = never
+	atomic.StoreUint32(&b.held, 0)
+	b.ledHold(false)
+}
+
+// handleEdge takes a pin transition, passing it through the glitch filter if there is one
+func (b *bouncer) handleEdge(e Edge) {
+	b.levelUp = e.Up
+	if b.recorder != nil {
+		b.recorder.add(e)
+	}
+	if b.trace != nil {
+		b.trace.add(e)
+	}
+	if b.glitch > 0 {
+		b.glitchEdge(e)
+		return
+	}
+	b.recognizeEdge(e)
+}
+
+// recognizeEdge advances the bounce sequence with a pin transition
+func (b *bouncer) recognizeEdge(e Edge) {
+	if b.mode == EStopMode {
+		b.handleEStopEdge(e)
+		return
+	}
+	if b.maintained() {
+		b.handleToggleEdge(e)
+		return
+	}
+	if b.mode == VibrationMode {
+		b.handleVibrationEdge(e)
+		return
+	}
+	switch e.Up {
+	case true: // button is 'up'
+		if b.ticks == 0 { // if we were awaiting a new bounce sequence to begin
+			return // ignore 'up' signal
+		} // otherwise we were awaiting the conclusion of a bounce sequence
+		if b.ticks > b.debounceTicks { // if the interval between down & up is greater than the debounce ticks
+			dur := e.Time.Sub(b.btnDown) // calculate sequence duration
+			elapsed := b.ticks - 1       // ticks counted since 'down'
+			b.ticks = 0                  // stop & reset ticks + look for new bounce sequence
+			if b.degradedBounce > 0 && b.wearTrend(b.bounceEnd.Sub(b.btnDown)) {
+				b.publish(Degraded)
+			}
+			b.btnDown = never // reset button down time
+			atomic.StoreUint32(&b.held, 0)
+			atomic.StoreUint32(&b.down, 0)
+			b.ledHold(false)
+			if atomic.SwapUint32(&b.modified, 0) == 1 { // we were used as a modifier; our own press is consumed
+				return
+			}
+			if atomic.SwapUint32(&b.swiped, 0) == 1 { // the press was half of a swipe
+				return
+			}
+			if b.stuck { // a stuck button coming free isn't a press
+				b.stuck = false
+				return
+			}
+			if b.cooling(e.Time) { // pumped again within the cooldown after the last press
+				return
+			}
+			if b.cooldown > 0 {
+				b.coolUntil = e.Time.Add(b.cooldown)
+			}
+			if b.gestured { // the press completed a gesture while held
+				b.gestured = false
+				return
+			}
+			if b.leading { // already published while held
+				if b.leadTier == LongPress && b.thresholds().extraLong != Disabled { // let go before the hold completed
+					b.publish(LongPressCanceled)
 				}
-			case false: // button is 'down'
-				if ticks == 0 { // if we were awaitng a new bounce sequence to begin
-					ticks = 1            // set ticks to 1 so that ticks begins to increment with each received systick
-					btnDown = time.Now() // set now as the beginning of the sequence
-					continue             // reset the loop
-				} // otherwise if we were awaiting the conclusion of a bounce sequence, ignore
+				return
+			}
+			// Recognize & publish to channel(s)
+			p := b.recognize(dur)
+			if b.shortTicks > 0 {
+				p = b.recognizeTicks(elapsed)
+			}
+			if p == Bounce && b.taps { // debounced, but quicker than a ShortPress
+				p = Tap
+			}
+			if b.max > 0 && dur > b.max { // held too long to be deliberate
+				p = StuckFault
 			}
+			b.click(p, e.Time)
+		} else { // ignore & await next buttonUp if debounce interval was not exceeded
+			b.bounce(e)
 		}
+	case false: // button is 'down'
+		if b.ticks == 0 { // if we were awaitng a new bounce sequence to begin
+			b.ticks = 1        // set ticks to 1 so that ticks begins to increment with each received systick
+			b.btnDown = e.Time // set the edge time as the beginning of the sequence
+			b.bounceEnd = e.Time
+			b.bounces = 0
+			b.leadTier = Bounce
+			b.gestured = false
+			atomic.StoreUint32(&b.held, 1)
+			b.ledHold(true)
+			if b.feedback != nil {
+				b.feedback.Pressed()
+			}
+			return
+		} // otherwise we were awaiting the conclusion of a bounce sequence; note the bounce & ignore it
+		b.bounce(e)
+	}
+}
+
+// cooling reports whether now is within the cooldown after the last press, forgetting a cooldown which has
+// passed. The bouncer keeps taking ticks until it has, so a compact Instant never wraps around past it
+func (b *bouncer) cooling(now Instant) bool {
+	if b.coolUntil.IsZero() {
+		return false
 	}
+	if now.Before(b.coolUntil) {
+		return true
+	}
+	b.coolUntil = never
+	return false
 }
 
-// Duration returns the duration of the passed-in PressLength
+// Duration returns the duration of the passed-in PressLength, or Disabled for a disabled tier
 func (b *bouncer) Duration(l PressLength) time.Duration {
+	t := b.thresholds()
 	switch l {
 	case ShortPress:
-		return b.shortPress
+		return t.short
 	case LongPress:
-		return b.longPress
+		return t.long
 	case ExtraLongPress:
-		return b.extraLongPress
+		return t.extraLong
 	default:
 		return 0
 	}
 }
 
-// publish concurrently sends a PressLength to all channels subscribed to this Bouncer
+// SubscribePriority adds a high-priority subscriber, which the recognizer sends to synchronously before
+// fanning out to regular subscribers. The recognizer waits for ch to be received from, so its reader must
+// keep up; use this for safety-relevant handlers only. Subscribe before starting RecognizeAndPublish
+func (b *bouncer) SubscribePriority(ch chan<- PressLength) {
+	b.priorityChans = append(b.priorityChans, ch)
+}
+
+// publish synchronously sends a PressLength to priority subscribers, then queues it for the dispatcher
+// to send to all channels subscribed to this Bouncer, or to its alternate channels while its modifier is held
 func (b *bouncer) publish(p PressLength) {
-	for i := range b.outChans {
-		go func(i int) {
-			b.outChans[i] <- p
-		}(i)
+	outs := b.outChans
+	if b.modifier != nil && b.modifier.debouncedDown() { // a glitch on the modifier mustn't shift this press
+		atomic.StoreUint32(&b.modifier.modified, 1)
+		outs = b.altChans
+	}
+	b.publishTo(p, outs)
+}
+
+// publishTo publishes a PressLength as publish does, but with outs in place of the bouncer's own channels
+func (b *bouncer) publishTo(p PressLength, outs []chan<- PressLength) {
+	if b.feedback != nil {
+		b.feedback.Recognized(p)
+	}
+	e := stamped(Event{Pin: b.pins[0], Name: b.name, Length: p, Bounces: b.bounces})
+	b.count(p)
+	b.remember(e)
+	for _, ch := range b.priorityChans {
+		ch <- transformed(ch, e).Length
+	}
+	if atomic.LoadUint32(&b.waiting) == 1 {
+		select {
+		case b.waitCh <- p:
+		default:
+		}
+	}
+	b.ledPattern(p)
+	var handler func()
+	if int(p) < len(b.handlers) {
+		handler = b.handlers[p]
+	}
+	enqueue(delivery{outs: outs, events: b.eventChans, bus: b.bus, event: e, handler: handler})
+}
+
+// stamped returns e stamped with the count of relayed ticks & the next sequence number, as every published Event is
+func stamped(e Event) Event {
+	e.Tick = atomic.LoadUint32(&relayTicks)
+	e.Seq = atomic.AddUint32(&published, 1)
+	return e
+}
+
+// click publishes a recognized PressLength, withholding ShortPresses for the click window
+// so that a ShortPress and a DoubleClick are never both published for the same gesture
+func (b *bouncer) click(p PressLength, at Instant) {
+	if b.mode == LatchMode && p == ShortPress {
+		b.publish(b.toggleLatch())
+		return
+	}
+	if b.clickWindow <= 0 {
+		b.publish(p)
+		return
+	}
+	if p != ShortPress {
+		b.flushClicks() // the press was not a click; release the withheld ones first
+		b.publish(p)
+		return
+	}
+	b.clicks++
+	b.clickAt = at
+	if b.clicks >= 2 && !b.gestureFollows(b.clicks) { // this press concludes a second click
+		b.clicks -= 2
+		b.publish(DoubleClick)
+	}
+}
+
+// flushClicks publishes the withheld ShortPresses, pairs of them as DoubleClicks
+func (b *bouncer) flushClicks() {
+	for ; b.clicks >= 2; b.clicks -= 2 {
+		b.publish(DoubleClick)
+	}
+	if b.clicks == 1 {
+		b.clicks = 0
+		b.publish(ShortPress)
 	}
 }
 
 // recognize returns a PressLength resulting from a passed-in duration matching a Bouncer's durations
 func (b *bouncer) recognize(d time.Duration) PressLength {
-	if d >= b.extraLongPress { // duration was extraLongPress
+	t := b.thresholds()
+	return classify(d, t.short, t.long, t.extraLong)
+}
+
+// recognizeTicks returns a PressLength resulting from a count of elapsed ticks matching a Bouncer's tick thresholds
+func (b *bouncer) recognizeTicks(n int) PressLength {
+	switch {
+	case b.extraLongTicks > 0 && n >= b.extraLongTicks:
 		return ExtraLongPress
-	} else if d < b.extraLongPress && d >= b.longPress { // duration was longPress
+	case b.longTicks > 0 && n >= b.longTicks:
 		return LongPress
-	} else if d < b.longPress && d >= b.shortPress { // duration was shortPress
+	case n >= b.shortTicks:
 		return ShortPress
 	}
-	return Bounce // should be unreachable
+	return Bounce
 }
 
-// addSysTickConsumer appends a channel to the pkg-level SysTickSubscriber slice.
-// each Bouncer is added to this slice in New and ticks are relayed by spawning RelayTicks
-func addSysTickConsumer(ch chan struct{}) {
-	sysTickSubcribers = append(sysTickSubcribers, sysTickSubscriber{channel: ch})
+// classify returns the PressLength of duration d against a set of thresholds
+func classify(d, short, long, extraLong time.Duration) PressLength {
+	if extraLong != Disabled && d >= extraLong { // duration was extraLongPress
+		return ExtraLongPress
+	} else if long != Disabled && d >= long { // duration was longPress
+		return LongPress
+	} else if d >= short { // duration was shortPress
+		return ShortPress
+	}
+	return Bounce // shorter than shortPress
 }
 
-// sendTicks sends a signal to each Bouncer in the package-level SysTickSubscribers slice
-func sendTicks() {
-	if len(sysTickSubcribers) > 0 {
-		for _, c := range sysTickSubcribers {
-			c.channel <- struct{}{}
+// addSysTickConsumer subscribes a channel to relayed ticks.
+// each Bouncer is subscribed in Configure and ticks are relayed by spawning RelayTicks
+func addSysTickConsumer(ch chan struct{}, listening *uint32) {
+	tickSubscribers.add(&sysTickSubscriber{channel: ch, listening: listening})
+}
+
+// sendTicks sends a signal to each listening Bouncer in the package-level tick registry;
+// idle bouncers are skipped so their goroutines stay asleep. A subscriber which hasn't taken its last tick
+// loses this one, counted in Metrics, so one stalled bouncer never holds up the rest. It reports whether every
+// listening subscriber took its tick, for the watchdog
+func sendTicks() bool {
+	delivered := true
+	atomic.AddUint32(&relayTicks, 1)
+	for _, c := range tickSubscribers.snapshot() {
+		if atomic.LoadUint32(c.listening) == 0 {
+			continue
 		}
+		if c.every > 1 {
+			if c.skipped++; c.skipped < c.every {
+				continue
+			}
+			c.skipped = 0
+		}
+		select {
+		case c.channel <- struct{}{}:
+		default:
+			atomic.AddUint32(&c.lost, 1)
+			delivered = false
+		}
+	}
+	if len(sharedBouncers.snapshot()) > 0 {
+		nudgeShared()
 	}
+	return delivered
 }
 
 // Debounce relays ticks from the SysTick_Handler to all bouncers;
 // and is intended to be called as a long-lived goroutine, and only once regarldess of how many bouncers you make.
-// The param tickCh is intended to be the same channel spammed by your SysTick_Handler
+// The param tickCh is intended to be the same channel spammed by your SysTick_Handler. It returns once StopRelay
+// (or Shutdown) is called
 func Debounce(tickCh chan struct{}) {
+	stop := currentRelayStop()
 	for {
 		select {
+		case <-stop: // StopRelay or Shutdown
+			for len(tickCh) > 0 { // drain ticks already waiting, so they aren't relayed late by the next relay
+				<-tickCh
+			}
+			return
 		case <-tickCh:
-			sendTicks()
+			if sendTicks() && watchdogKick != nil {
+				watchdogKick()
+			}
 		}
 	}
 }
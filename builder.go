@@ -0,0 +1,87 @@
+package bouncer
+
+import (
+	"time"
+
+	"machine"
+)
+
+// Builder sets up a bouncer fluently, as an alternative to New & Configure:
+//
+//	btn, err := bouncer.For(machine.D3).Short(20 * time.Millisecond).ActiveHigh().Subscribe(ch).Build()
+//
+// Nothing is checked or touched until Build
+type Builder struct {
+	pin machine.Pin
+	o   options
+}
+
+// For starts building a bouncer for the pin
+func For(p machine.Pin) *Builder {
+	return &Builder{pin: p}
+}
+
+// Short sets the short press threshold
+func (b *Builder) Short(d time.Duration) *Builder {
+	b.o.cfg.Short = d
+	return b
+}
+
+// Long sets the long press threshold (or Disabled)
+func (b *Builder) Long(d time.Duration) *Builder {
+	b.o.cfg.Long = d
+	return b
+}
+
+// ExtraLong sets the extra long press threshold (or Disabled)
+func (b *Builder) ExtraLong(d time.Duration) *Builder {
+	b.o.cfg.ExtraLong = d
+	return b
+}
+
+// ActiveHigh is for buttons which pull the pin high when pressed
+func (b *Builder) ActiveHigh() *Builder {
+	b.o.cfg.Polarity = ActiveHigh
+	return b
+}
+
+// DebounceTicks sets how many systicks must elapse between 'down' and 'up' for a press to count
+func (b *Builder) DebounceTicks(n int) *Builder {
+	b.o.cfg.DebounceTicks = n
+	return b
+}
+
+// Mode sets how the bouncer interprets its pin
+func (b *Builder) Mode(m Mode) *Builder {
+	b.o.cfg.Mode = m
+	return b
+}
+
+// Name identifies the bouncer in Events
+func (b *Builder) Name(name string) *Builder {
+	b.o.cfg.Name = name
+	return b
+}
+
+// Config starts from a whole Config, for settings without a method of their own; later calls override its fields
+func (b *Builder) Config(cfg Config) *Builder {
+	b.o.cfg = cfg
+	return b
+}
+
+// Subscribe adds an output channel
+func (b *Builder) Subscribe(ch chan<- PressLength) *Builder {
+	b.o.outs = append(b.o.outs, ch)
+	return b
+}
+
+// Handle calls f whenever the bouncer publishes l
+func (b *Builder) Handle(l PressLength, f func()) *Builder {
+	WithHandler(l, f)(&b.o)
+	return b
+}
+
+// Build validates everything set, then makes & configures the bouncer. It needs a subscriber or a handler
+func (b *Builder) Build() (Bouncer, error) {
+	return b.o.build(b.pin)
+}
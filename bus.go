@@ -0,0 +1,93 @@
+package bouncer
+
+import (
+	"sync/atomic"
+
+	"machine"
+)
+
+// AnyPin matches events from every pin in a Topic
+const AnyPin = machine.NoPin
+
+// Event is a PressLength tagged with the identity of the bouncer that published it
+type Event struct {
+	Pin    machine.Pin // the bouncer's (first) pin
+	Name   string      // Config.Name of the bouncer
+	Length PressLength
+	// Bounces is how many bounce edges were suppressed during the press, the cheapest signal of a switch's
+	// quality; it saturates at 255
+	Bounces uint8
+	// Tick is the package-wide count of relayed ticks when the event was published, and Seq the count of events
+	// published by every bouncer, this one included. Sorting by Seq orders events from several bouncers as they
+	// were recognized, however their channels delivered them; both wrap around
+	Tick uint32
+	Seq  uint32
+	// Replayed marks a stale event, sent to a new bus subscriber by Topic.Replay rather than newly published
+	Replayed bool
+}
+
+// Topic selects the Events a bus subscriber receives; the zero value of each field except Pin
+// matches everything, so use AnyPin to match all pins
+type Topic struct {
+	Pin     machine.Pin
+	Name    string
+	Lengths []PressLength
+	// Transform, when set, adapts each matching Event before it's sent to this subscriber only,
+	// e.g. to invert or remap its PressLength
+	Transform func(Event) Event
+	// Replay has Subscribe send the new subscriber each matching bouncer's most recent Event, plus a Held
+	// Event for any button down at that moment, so a screen mounted after boot can catch up
+	Replay bool
+}
+
+type busSubscriber struct {
+	topic   Topic
+	channel chan<- Event
+}
+
+var busSubscribers atomic.Value // []busSubscriber, replaced wholesale under registryMu
+
+// busSnapshot returns the current bus subscribers; it mustn't be modified
+func busSnapshot() []busSubscriber {
+	subs, _ := busSubscribers.Load().([]busSubscriber)
+	return subs
+}
+
+// deliver sends e to the subscriber, through its topic's Transform if it has one
+func (s busSubscriber) deliver(e Event) {
+	if s.topic.Transform != nil {
+		e = s.topic.Transform(e)
+	}
+	s.channel <- e
+}
+
+// Subscribe adds ch to the package-level event bus, receiving every Event from bouncers configured with Bus
+// which matches the topic. It's safe to Subscribe while bouncers are publishing
+func Subscribe(t Topic, ch chan<- Event) {
+	registryMu.Lock()
+	old := busSnapshot()
+	subs := make([]busSubscriber, len(old), len(old)+1)
+	copy(subs, old)
+	sub := busSubscriber{topic: t, channel: ch}
+	busSubscribers.Store(append(subs, sub))
+	registryMu.Unlock()
+	if !t.Replay {
+		return
+	}
+	for _, b := range registered.snapshot() {
+		if b.bus {
+			b.replay(sub)
+		}
+	}
+}
+
+// matches reports whether e belongs to the topic
+func (t Topic) matches(e Event) bool {
+	if t.Pin != AnyPin && t.Pin != e.Pin {
+		return false
+	}
+	if t.Name != "" && t.Name != e.Name {
+		return false
+	}
+	return wanted(e.Length, t.Lengths)
+}
@@ -0,0 +1,124 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// calibrationGap is the quiet time which separates one burst of bounce from the next
+const calibrationGap = 30 * time.Millisecond
+
+// Calibration is what Calibrate measured of a switch, along with the Config it recommends
+type Calibration struct {
+	WorstBounce   time.Duration // the longest burst of edges around any transition
+	ShortestPress time.Duration // from the first edge of the shortest press to the first edge of its release
+	Config        Config        // Short set midway between the two; apply it with Configure on a new bouncer
+}
+
+// Calibrate waits for the given number of test presses on a configured bouncer, measuring the worst-case
+// bounce envelope and the shortest intentional press, and recommends a Short threshold between the two.
+// Ask the user for a few quick presses first. Calibrate reads the bouncer's edges itself, so call it before
+// starting RecognizeAndPublish (presses made during calibration aren't published)
+func (b *bouncer) Calibrate(presses int) (Calibration, error) {
+	if b.emit == nil {
+		return Calibration{}, errors.New(ERROR_NOT_CONFIGURED)
+	}
+	if presses < 1 {
+		return Calibration{}, errors.New(ERROR_NO_PRESSES)
+	}
+	b.setListening(true)
+	defer func() { b.setListening(b.needsTicks()) }()
+	var c Calibration
+	level := b.get()
+	var burstStart, burstEnd, downAt Instant
+	var down bool // the current burst began with a press
+	for n := 0; ; {
+		timeout := time.Duration(0) // wait indefinitely for the next press
+		if !burstStart.IsZero() {
+			timeout = calibrationGap
+		}
+		e, ok := b.nextEdge(&level, timeout, false)
+		if ok && !burstStart.IsZero() && e.Time.Sub(burstEnd) < calibrationGap { // more of the same burst
+			burstEnd = e.Time
+			continue
+		}
+		if !burstStart.IsZero() { // the burst is over
+			if d := burstEnd.Sub(burstStart); d > c.WorstBounce {
+				c.WorstBounce = d
+			}
+			if !down && !downAt.IsZero() {
+				if d := burstStart.Sub(downAt); c.ShortestPress == 0 || d < c.ShortestPress {
+					c.ShortestPress = d
+				}
+				downAt = never
+				if n++; n == presses {
+					break
+				}
+			}
+		}
+		if !ok {
+			burstStart = never
+			continue
+		}
+		burstStart, burstEnd, down = e.Time, e.Time, !e.Up
+		if down {
+			downAt = e.Time
+		}
+	}
+	c.Config.Short = (c.WorstBounce + c.ShortestPress) / 2
+	return c, nil
+}
+
+// nextEdge waits up to timeout (or indefinitely if timeout is zero) for the bouncer's next raw edge, from
+// isrChan, the ring or the edge flag or, for bouncers whose edges are picked up on ticks, by sampling the pins
+// against level. With isrOnly, an interrupt-backed bouncer whose pins change level for two ticks without an
+// edge from its interrupt handler fails, reporting the level it changed to
+func (b *bouncer) nextEdge(level *bool, timeout time.Duration, isrOnly bool) (Edge, bool) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		expired = t.C
+	}
+	missed := false // the level has changed without an edge for a tick
+	for {
+		select {
+		case e := <-b.isrChan:
+			*level = e.Up
+			return e, true
+		case <-b.tickerCh:
+			if b.isrRing != nil {
+				if e, ok := b.isrRing.get(); ok {
+					*level = e.Up
+					return e, true
+				}
+			}
+			if b.edgeFlag != nil {
+				if e, ok := b.edgeFlag.take(); ok {
+					*level = e.Up
+					return e, true
+				}
+			}
+			up := b.get()
+			if up == *level {
+				missed = false
+				continue
+			}
+			if isrOnly && b.InterruptBacked() {
+				if !missed { // give an edge racing this tick one more tick to arrive
+					missed = true
+					continue
+				}
+				*level = up
+				return Edge{Up: up, Time: clockNow()}, false
+			}
+			if b.isrRing != nil || b.edgeFlag != nil {
+				continue
+			}
+			*level = up
+			return Edge{Up: up, Time: clockNow()}, true
+		case <-expired:
+			return Edge{}, false
+		}
+	}
+}
@@ -0,0 +1,52 @@
+//go:build !bouncer_poll
+
+package bouncer
+
+import (
+	"errors"
+
+	"machine"
+)
+
+// attachCapture wires up how edges reach the recognizer: the hardware filter, a batched port interrupt, or an
+// interrupt per pin, falling back to SENSE or polling for pins whose interrupt can't be had. Builds with the
+// bouncer_poll tag replace it with polling alone, compiling all of this out
+func (b *bouncer) attachCapture(cfg Config, emit func(up bool)) error {
+	if cfg.HardwareFilter > 0 {
+		return b.attachFilters(cfg.HardwareFilter, emit)
+	}
+	if err := claimLines(b.pins); err != nil {
+		return err
+	}
+	if cfg.Port {
+		return b.attachPort(emit)
+	}
+	handler := func(machine.Pin) {
+		emit(b.get())
+	}
+	change := machine.PinFalling | machine.PinRising
+	switch cfg.Edges {
+	case FallingEdges:
+		change = machine.PinFalling
+	case RisingEdges:
+		change = machine.PinRising
+	}
+	b.edgeScheme = cfg.Edges
+	for _, p := range b.pins {
+		err := p.SetInterrupt(change, handler)
+		if err == nil {
+			continue
+		}
+		if cfg.SenseFallback {
+			if err := b.attachSense(p); err != nil {
+				return err
+			}
+			continue
+		}
+		if !cfg.PollFallback {
+			return &ConfigError{Pin: p, Err: errors.New(ERROR_INTERRUPT_UNAVAILABLE + ": " + err.Error())}
+		}
+		b.polled = true
+	}
+	return nil
+}
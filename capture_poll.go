@@ -0,0 +1,16 @@
+//go:build bouncer_poll
+
+package bouncer
+
+import "errors"
+
+// attachCapture samples the pins on every tick instead of taking interrupts, for targets too small (or too
+// unreliable in interrupt context) for the interrupt machinery, which this build leaves out. Port, Edges &
+// the fallbacks have no interrupts to act on, so they're ignored; the hardware filter can't be had
+func (b *bouncer) attachCapture(cfg Config, emit func(up bool)) error {
+	if cfg.HardwareFilter > 0 {
+		return errors.New(ERROR_FILTER_UNSUPPORTED)
+	}
+	b.polled = true
+	return nil
+}
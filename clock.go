@@ -0,0 +1,40 @@
+package bouncer
+
+import "time"
+
+// Clock is the package's source of time. Every timestamp & duration the recognizers use is read through it
+type Clock interface {
+	Now() time.Time
+}
+
+// MicrosClock is a Clock which can also read a free-running 32-bit microsecond counter, such as a hardware
+// timer. Builds with the bouncer_compact tag read Micros instead of Now, so taking a timestamp needs no 64-bit math
+type MicrosClock interface {
+	Clock
+	Micros() uint32
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+var clock Clock = systemClock{}
+
+// SetClock replaces the package's time source, e.g. with a fake clock in tests, or a cycle-counter-based clock on
+// targets where time.Now is broken or expensive; nil restores time.Now. Set it before configuring any bouncers.
+// Sleeps (Replay & StartTimerTicking) still use the runtime's timers
+func SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	clock = c
+	clockChanged()
+}
+
+// never is the zero Instant, meaning unset
+var never Instant
+
+// clockSince returns the time elapsed on the package clock since t
+func clockSince(t Instant) time.Duration {
+	return clockNow().Sub(t)
+}
@@ -0,0 +1,142 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// Step is one press in a Combo: the Config.Name of the bouncer and the PressLength it must publish
+type Step struct {
+	Name   string
+	Length PressLength
+}
+
+// Combo is a sequence of presses, possibly across several bouncers, such as "A short, A short, B long"
+type Combo struct {
+	Name    string // published as the Name of the ComboMatched Event
+	Steps   []Step
+	Timeout time.Duration // the whole sequence must be completed within this; zero defaults to 2s
+}
+
+type comboMatcher struct {
+	combos   []Combo
+	progress []int       // steps matched so far, per combo
+	stamps   [][]Instant // time each matched step was pressed, per combo
+	fallback [][]int     // per combo & step, how many steps are still matched if the next press doesn't fit
+	in       chan Event
+	outs     []chan<- Event
+}
+
+// ComboMatcher recognizes registered Combos in the Events of every bouncer configured with Bus
+type ComboMatcher interface {
+	Register(Combo) error
+	Run()
+}
+
+// NewComboMatcher returns a ComboMatcher (or error) publishing a ComboMatched Event on outs for each Combo
+// completed. It subscribes to the event bus itself, so call it during setup along with Subscribe
+func NewComboMatcher(outs ...chan<- Event) (ComboMatcher, error) {
+	if len(outs) < 1 {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	m := &comboMatcher{in: make(chan Event, 4), outs: outs}
+	Subscribe(Topic{Pin: AnyPin}, m.in)
+	return m, nil
+}
+
+// Register adds a Combo to be recognized; register before starting Run
+func (m *comboMatcher) Register(c Combo) error {
+	if len(c.Steps) < 1 {
+		return errors.New(ERROR_NO_STEPS)
+	}
+	if c.Timeout < 0 {
+		return errors.New(ERROR_NEGATIVE_DURATION)
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 2 * time.Second
+	}
+	m.combos = append(m.combos, c)
+	m.progress = append(m.progress, 0)
+	m.stamps = append(m.stamps, make([]Instant, len(c.Steps)))
+	m.fallback = append(m.fallback, fallbacks(c.Steps))
+	return nil
+}
+
+// fallbacks returns, for each number of steps matched, the longest proper prefix of steps which is also a suffix
+// of those matched, so that a press which breaks a sequence can resume an overlapping one: "A A B" still
+// matches "A A A B" (the KMP failure function)
+func fallbacks(steps []Step) []int {
+	f := make([]int, len(steps)+1)
+	k := 0
+	for i := 1; i < len(steps); i++ {
+		for k > 0 && steps[i] != steps[k] {
+			k = f[k]
+		}
+		if steps[i] == steps[k] {
+			k++
+		}
+		f[i+1] = k
+	}
+	return f
+}
+
+// Run should be a goroutine; it advances every registered Combo with each press from the bus. A press which
+// doesn't fit a combo falls back to the longest run of steps still matched, as does a combo whose first
+// matched step has timed out. Bounces & status events (heartbeats, Idle, faults...) are ignored. ComboMatched is
+// stamped & handed to the dispatcher like any bouncer's event
+func (m *comboMatcher) Run() {
+	startDispatcher()
+	for e := range m.in {
+		if !isPress(e.Length) {
+			continue
+		}
+		now := clockNow()
+		for i := range m.combos {
+			m.advance(i, e, now)
+		}
+	}
+}
+
+// advance feeds one press to combo i, publishing ComboMatched when it completes
+func (m *comboMatcher) advance(i int, e Event, now Instant) {
+	c := &m.combos[i]
+	for m.progress[i] > 0 && now.Sub(m.stamps[i][0]) > c.Timeout {
+		m.fallBack(i)
+	}
+	for m.progress[i] > 0 && !c.Steps[m.progress[i]].matches(e) {
+		m.fallBack(i)
+	}
+	if !c.Steps[m.progress[i]].matches(e) {
+		return
+	}
+	m.stamps[i][m.progress[i]] = now
+	m.progress[i]++
+	if m.progress[i] < len(c.Steps) {
+		return
+	}
+	m.progress[i] = 0
+	enqueue(delivery{events: m.outs, event: stamped(Event{Pin: AnyPin, Name: c.Name, Length: ComboMatched})})
+}
+
+// fallBack drops combo i's earliest matched steps, keeping the longest run still matching its first steps
+func (m *comboMatcher) fallBack(i int) {
+	p := m.progress[i]
+	k := m.fallback[i][p]
+	copy(m.stamps[i], m.stamps[i][p-k:p])
+	m.progress[i] = k
+}
+
+// isPress reports whether p is something done to a button, as opposed to a bounce or a report of its condition
+func isPress(p PressLength) bool {
+	switch p {
+	case ShortPress, LongPress, ExtraLongPress, DoubleClick, On, Off, Tap, Closed, Open, Vibration, TapHold,
+		DoubleTapHold, SwipeNext, SwipePrevious, LongPressCanceled, StateOn, StateOff:
+		return true
+	}
+	return false
+}
+
+// matches reports whether e is the press the step calls for
+func (s Step) matches(e Event) bool {
+	return s.Name == e.Name && s.Length == e.Length
+}
@@ -0,0 +1,28 @@
+//go:build !bouncer_nostats
+
+package bouncer
+
+import "sync/atomic"
+
+// Counts returns how many of each PressLength the bouncer has published since it was made, or since ResetCounts
+func (b *bouncer) Counts() PressCounts {
+	var c PressCounts
+	for i := range b.counts {
+		c[i] = atomic.LoadUint32(&b.counts[i])
+	}
+	return c
+}
+
+// ResetCounts zeroes the bouncer's counts
+func (b *bouncer) ResetCounts() {
+	for i := range b.counts {
+		atomic.StoreUint32(&b.counts[i], 0)
+	}
+}
+
+// count adds a published PressLength to the bouncer's counts
+func (b *bouncer) count(p PressLength) {
+	if int(p) < len(b.counts) {
+		atomic.AddUint32(&b.counts[p], 1)
+	}
+}
@@ -0,0 +1,114 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"machine"
+)
+
+// dipStableTicks is how many consecutive tick samples a switch must hold before its bit changes
+const dipStableTicks = 3
+
+type dipBank struct {
+	pins      []machine.Pin
+	tickerCh  chan struct{} // produced by sendTicks -> consumed by Run
+	done      chan struct{} // closed by Shutdown, ending Run
+	stopped   uint32        // set once Shutdown has stopped the device
+	outChans  []chan<- uint // receive the bank's new Value whenever any switch flips
+	value     uint32        // debounced snapshot, set atomically so Value can read it
+	counts    []uint8       // consecutive samples for which each bit has disagreed with value
+	listening uint32        // DIP banks are polled, so they always listen for ticks
+}
+
+// DIPBank reads a group of pins as a bank of DIP switches, one bit per pin
+type DIPBank interface {
+	Configure() error
+	Run()
+	Value() uint
+}
+
+// NewDIPBank returns a new DIPBank (or error) reading pins as bits 0..n-1, which publishes its new Value
+// on the given channels whenever a switch flips. A closed switch (pin pulled low) is a 1 bit
+func NewDIPBank(pins []machine.Pin, outs ...chan<- uint) (DIPBank, error) {
+	if len(pins) < 1 {
+		return nil, errors.New(ERROR_NO_PINS)
+	}
+	if len(pins) > 32 {
+		return nil, errors.New(ERROR_TOO_MANY_PINS)
+	}
+	return &dipBank{
+		pins:     append([]machine.Pin(nil), pins...),
+		tickerCh: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		outChans: outs,
+		counts:   make([]uint8, len(pins)),
+	}, nil
+}
+
+// Configure sets the pins to InputPullup, takes an initial snapshot, and subscribes the bank to ticks
+func (d *dipBank) Configure() error {
+	for _, p := range d.pins {
+		p.Configure(machine.PinConfig{Mode: machine.PinInputPullup})
+	}
+	atomic.StoreUint32(&d.value, d.sample())
+	addSysTickConsumer(d.tickerCh, &d.listening)
+	listen(&d.listening, true)
+	devices.add(d)
+	return nil
+}
+
+// Value returns the debounced snapshot of the bank
+func (d *dipBank) Value() uint {
+	return uint(atomic.LoadUint32(&d.value))
+}
+
+// Run should be a goroutine; samples the bank on each tick, debounces each bit,
+// and publishes the new Value when any switch has flipped
+func (d *dipBank) Run() {
+	for {
+		select {
+		case <-d.tickerCh:
+		case <-d.done:
+			return
+		}
+		v := atomic.LoadUint32(&d.value)
+		next := v
+		raw := d.sample()
+		for i := range d.pins {
+			bit := uint32(1) << i
+			if raw&bit == v&bit {
+				d.counts[i] = 0
+				continue
+			}
+			d.counts[i] += 1
+			if d.counts[i] >= dipStableTicks {
+				d.counts[i] = 0
+				next ^= bit
+			}
+		}
+		if next == v {
+			continue
+		}
+		atomic.StoreUint32(&d.value, next)
+		for _, ch := range d.outChans {
+			ch <- uint(next)
+		}
+	}
+}
+
+// sample reads the raw state of the bank
+func (d *dipBank) sample() uint32 {
+	var v uint32
+	for i, p := range d.pins {
+		if !p.Get() {
+			v |= 1 << i
+		}
+	}
+	return v
+}
+
+// stop ends Run, for Shutdown
+func (d *dipBank) stop() {
+	halt(d.tickerCh, d.done, &d.listening, &d.stopped)
+}
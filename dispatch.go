@@ -0,0 +1,66 @@
+package bouncer
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// dispatchQueueSize bounds the events waiting for delivery across all bouncers
+const dispatchQueueSize = 16
+
+// delivery is one event on its way to a bouncer's subscribers
+type delivery struct {
+	outs    []chan<- PressLength
+	events  []chan<- Event // subscribers to the whole Event, such as a Group's channel
+	bus     bool           // also deliver to matching event bus subscribers
+	event   Event
+	handler func() // registered for the event's PressLength with Handle, if any
+}
+
+var (
+	dispatchQueue = make(chan delivery, dispatchQueueSize)
+	dispatchOnce  sync.Once
+	droppedEvents uint32 // deliveries discarded because dispatchQueue (or, under Update, a subscriber) was full
+)
+
+// startDispatcher launches the package's single dispatcher goroutine, once
+func startDispatcher() {
+	dispatchOnce.Do(func() {
+		go dispatch()
+	})
+}
+
+// enqueue hands a delivery to the dispatcher without blocking the recognizer;
+// if the queue is full the delivery is dropped and counted
+func enqueue(d delivery) {
+	select {
+	case dispatchQueue <- d:
+	default:
+		atomic.AddUint32(&droppedEvents, 1)
+	}
+}
+
+// dispatch is the long-lived goroutine delivering every bouncer's events. Deliveries are made one at a time,
+// to each subscriber in turn, so every subscriber sees events in the order they were recognized.
+// A subscriber which stops receiving holds up the others, so give slow consumers buffered channels
+func dispatch() {
+	for d := range dispatchQueue {
+		for _, ch := range d.outs {
+			ch <- transformed(ch, d.event).Length
+		}
+		for _, ch := range d.events {
+			ch <- d.event
+		}
+		if d.handler != nil {
+			d.handler()
+		}
+		if !d.bus {
+			continue
+		}
+		for _, sub := range busSnapshot() {
+			if sub.topic.matches(d.event) {
+				sub.deliver(d.event)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package bouncer
+
+import "sync/atomic"
+
+// edgeFlag holds the latest edge left by the pin interrupt handler, for the recognizer to pick up on its next
+// tick. The handler only writes memory and bumps seq, so it makes no channel operation at all; edges between
+// ticks coalesce into the latest, as a press shorter than a tick would only have been a bounce
+type edgeFlag struct {
+	seq  uint32 // bumped before & after each write by the handler, so it's odd mid-write
+	seen uint32 // seq when the recognizer last took an edge; only touched by the recognizer
+	up   bool
+	at   Instant
+}
+
+// set latches an edge; called from interrupt context
+func (f *edgeFlag) set(up bool, at Instant) {
+	atomic.AddUint32(&f.seq, 1)
+	f.up, f.at = up, at
+	atomic.AddUint32(&f.seq, 1)
+}
+
+// take returns the latched edge if one is pending, rereading if the handler wrote it meanwhile
+func (f *edgeFlag) take() (Edge, bool) {
+	for {
+		s := atomic.LoadUint32(&f.seq)
+		if s == f.seen {
+			return Edge{}, false
+		}
+		if s&1 == 1 {
+			continue
+		}
+		e := Edge{Up: f.up, Time: f.at}
+		if atomic.LoadUint32(&f.seq) == s {
+			f.seen = s
+			return e, true
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package bouncer
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"machine"
+)
+
+// Format selects the wire format of Stream
+type Format uint8
+
+const (
+	Binary Format = iota // length-prefixed frames; see AppendBinary
+	JSON                 // one JSON object per line; see AppendJSON
+)
+
+// wireVersion is the first byte of every binary frame after its length, bumped whenever the layout changes
+const wireVersion = 3
+
+var pressLengthNames = [...]string{
+	Bounce:            "Bounce",
+	ShortPress:        "ShortPress",
+	LongPress:         "LongPress",
+	ExtraLongPress:    "ExtraLongPress",
+	DoubleClick:       "DoubleClick",
+	On:                "On",
+	Off:               "Off",
+	Tap:               "Tap",
+	StuckFault:        "StuckFault",
+	Closed:            "Closed",
+	Open:              "Open",
+	Vibration:         "Vibration",
+	TapHold:           "TapHold",
+	DoubleTapHold:     "DoubleTapHold",
+	SwipeNext:         "SwipeNext",
+	SwipePrevious:     "SwipePrevious",
+	LongPressCanceled: "LongPressCanceled",
+	ComboMatched:      "ComboMatched",
+	Degraded:          "Degraded",
+	StateOn:           "StateOn",
+	StateOff:          "StateOff",
+	WireFault:         "WireFault",
+	Held:              "Held",
+	Released:          "Released",
+	Idle:              "Idle",
+	Active:            "Active",
+	Activate:          "Activate",
+	Deactivate:        "Deactivate",
+	EStop:             "EStop",
+}
+
+// String returns the name of the PressLength
+func (p PressLength) String() string {
+	if int(p) < len(pressLengthNames) {
+		return pressLengthNames[p]
+	}
+	return "PressLength(" + strconv.Itoa(int(p)) + ")"
+}
+
+// AppendBinary appends the Event to buf as a frame of
+//
+//	[frame length] [version] [PressLength] [pin] [bounces] [tick, 4 bytes] [seq, 4 bytes] [name...]
+//
+// where frame length counts the bytes after itself, tick & seq are little-endian, and names are truncated to fit
+func (e Event) AppendBinary(buf []byte) []byte {
+	name := e.Name
+	if len(name) > 243 {
+		name = name[:243]
+	}
+	buf = append(buf, byte(12+len(name)), wireVersion, byte(e.Length), byte(e.Pin), e.Bounces)
+	buf = appendUint32(buf, e.Tick)
+	buf = appendUint32(buf, e.Seq)
+	return append(buf, name...)
+}
+
+// appendUint32 appends v to buf, little-endian
+func appendUint32(buf []byte, v uint32) []byte {
+	return append(buf, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// readUint32 reads a little-endian uint32 from the start of b
+func readUint32(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+// MarshalBinary returns the Event as a binary frame
+func (e Event) MarshalBinary() ([]byte, error) {
+	return e.AppendBinary(make([]byte, 0, 13+len(e.Name))), nil
+}
+
+// UnmarshalBinary decodes a binary frame produced by AppendBinary, or by an older encoder: version 1 frames
+// have no bounces, and version 2 no tick or seq
+func (e *Event) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 || int(data[0]) != len(data)-1 {
+		return errors.New(ERROR_INVALID_FRAME)
+	}
+	e.Length = PressLength(data[2])
+	e.Pin = machine.Pin(data[3])
+	e.Bounces, e.Tick, e.Seq = 0, 0, 0
+	switch data[1] {
+	case 1:
+		e.Name = string(data[4:])
+	case 2:
+		if len(data) < 5 {
+			return errors.New(ERROR_INVALID_FRAME)
+		}
+		e.Bounces = data[4]
+		e.Name = string(data[5:])
+	case wireVersion:
+		if len(data) < 13 {
+			return errors.New(ERROR_INVALID_FRAME)
+		}
+		e.Bounces = data[4]
+		e.Tick = readUint32(data[5:])
+		e.Seq = readUint32(data[9:])
+		e.Name = string(data[13:])
+	default:
+		return errors.New(ERROR_UNKNOWN_WIRE_VERSION)
+	}
+	return nil
+}
+
+// AppendJSON appends the Event to buf as a JSON object, e.g.
+// {"pin":3,"name":"fire","event":"LongPress","bounces":2,"tick":1042,"seq":17}
+func (e Event) AppendJSON(buf []byte) []byte {
+	buf = append(buf, `{"pin":`...)
+	buf = strconv.AppendUint(buf, uint64(e.Pin), 10)
+	buf = append(buf, `,"name":`...)
+	buf = appendJSONString(buf, e.Name)
+	buf = append(buf, `,"event":`...)
+	buf = appendJSONString(buf, e.Length.String())
+	buf = append(buf, `,"bounces":`...)
+	buf = strconv.AppendUint(buf, uint64(e.Bounces), 10)
+	buf = append(buf, `,"tick":`...)
+	buf = strconv.AppendUint(buf, uint64(e.Tick), 10)
+	buf = append(buf, `,"seq":`...)
+	buf = strconv.AppendUint(buf, uint64(e.Seq), 10)
+	return append(buf, '}')
+}
+
+// appendJSONString appends s as a quoted JSON string
+func appendJSONString(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			buf = append(buf, '\\', c)
+		case c < 0x20:
+			buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return append(buf, '"')
+}
+
+// Stream should be a goroutine; it writes each Event received on ch to w (typically a machine.UART, or an RTT
+// writer) in the given Format, reusing one buffer so streaming doesn't allocate. JSON objects are newline-terminated
+func Stream(ch <-chan Event, w io.Writer, f Format) {
+	buf := make([]byte, 0, 64)
+	for e := range ch {
+		buf = buf[:0]
+		if f == JSON {
+			buf = append(e.AppendJSON(buf), '\n')
+		} else {
+			buf = e.AppendBinary(buf)
+		}
+		w.Write(buf)
+	}
+}
@@ -0,0 +1,51 @@
+package bouncer
+
+import (
+	"strconv"
+	"time"
+
+	"machine"
+)
+
+// ConfigError is the error Configure returns, identifying which bouncer (and which of its pins) is misconfigured,
+// so a device with ten buttons says which one. Err is the underlying error
+type ConfigError struct {
+	Pin  machine.Pin
+	Name string // Config.Name, if any
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	s := "bouncer"
+	if e.Name != "" {
+		s += " " + strconv.Quote(e.Name)
+	}
+	return s + " on pin " + strconv.Itoa(int(e.Pin)) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, for errors.Is & errors.As
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// OverrunError is sent to the error channels when a recognizer took longer than one tick period to handle
+// an edge or a tick, usually because a subscriber didn't receive promptly
+type OverrunError struct {
+	Pin    machine.Pin
+	Name   string        // Config.Name, if any
+	Tick   bool          // handling a tick overran, rather than an edge
+	Took   time.Duration // how long the handling took
+	Period time.Duration // the tick period it overran: the mean interval between ticks
+}
+
+func (e *OverrunError) Error() string {
+	s := "bouncer"
+	if e.Name != "" {
+		s += " " + strconv.Quote(e.Name)
+	}
+	what := "edge"
+	if e.Tick {
+		what = "tick"
+	}
+	return s + " on pin " + strconv.Itoa(int(e.Pin)) + ": handling a " + what + " took " + e.Took.String() + ", over the " + e.Period.String() + " tick period"
+}
@@ -0,0 +1,31 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// handleEStopEdge publishes EStop the moment the circuit opens, without waiting for a press length, and latches
+// the fault; further edges are ignored until ResetFault
+func (b *bouncer) handleEStopEdge(e Edge) {
+	if e.Up || atomic.LoadUint32(&b.faulted) == 1 {
+		return
+	}
+	atomic.StoreUint32(&b.faulted, 1)
+	b.publish(EStop)
+}
+
+// Faulted reports whether an EStopMode bouncer's fault is latched
+func (b *bouncer) Faulted() bool {
+	return atomic.LoadUint32(&b.faulted) == 1
+}
+
+// ResetFault clears an EStopMode bouncer's latched fault, so it can publish EStop again. It refuses while the
+// button is still pressed (or its wire is still open), leaving the fault latched
+func (b *bouncer) ResetFault() error {
+	if b.mode == EStopMode && !b.get() {
+		return errors.New(ERROR_FAULT_ACTIVE)
+	}
+	atomic.StoreUint32(&b.faulted, 0)
+	return nil
+}
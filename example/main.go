@@ -15,7 +15,7 @@ var (
 )
 
 func launchSystick() {
-	err := arm.SetupSystemTimer(machine.CPUFrequency() / 40)
+	err := arm.SetupSystemTimer(machine.CPUFrequency() / 50)
 	if err != nil {
 		println("error launching systick timer")
 	}
@@ -31,12 +31,13 @@ func handleSystick() {
 
 func main() {
 	launchSystick()
+	bouncer.SetTickRate(50)
 	btn, err := bouncer.New(machine.D3, aliceChan, bobChan)
 	if err != nil {
 		println("couldn't make new bouncer")
 	}
 	err = btn.Configure(bouncer.Config{
-		Short:     18 * time.Millisecond,
+		Short:     20 * time.Millisecond,
 		Long:      550 * time.Millisecond,
 		ExtraLong: 1500 * time.Millisecond,
 	})
@@ -51,7 +52,7 @@ func main() {
 	select {}
 }
 
-func reactToPresses(name string, ch chan bouncer.PressLength) {
+func reactToPresses(name string, ch <-chan bouncer.PressLength) {
 	for {
 		select {
 		case pl := <-ch:
@@ -62,6 +63,8 @@ func reactToPresses(name string, ch chan bouncer.PressLength) {
 				println(name + " got a long press")
 			case bouncer.ExtraLongPress:
 				println(name + " got an extra long press")
+			case bouncer.DoubleClick:
+				println(name + " got a double click")
 			case bouncer.Bounce:
 				println(name + " got a bounce")
 			}
@@ -0,0 +1,12 @@
+package bouncer
+
+// Feedback drives a vibration motor, piezo or similar from inside the recognizer, without the latency of a
+// subscriber channel. Its methods run on the recognizer goroutine, so they must return quickly:
+// start a pulse and return rather than sleeping through it
+type Feedback interface {
+	// Pressed is called as soon as the 'down' edge beginning a press is seen.
+	// The first edge out of idle is genuine, so this is already debounced
+	Pressed()
+	// Recognized is called with each PressLength just before it is published
+	Recognized(PressLength)
+}
@@ -0,0 +1,16 @@
+//go:build !rp2040 || bouncer_nofilter
+
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// attachFilters is unsupported on this target, or compiled out by the bouncer_nofilter tag
+func (b *bouncer) attachFilters(d time.Duration, emit func(up bool)) error {
+	return errors.New(ERROR_FILTER_UNSUPPORTED)
+}
+
+// detachFilters has nothing to detach on this target
+func (b *bouncer) detachFilters() {}
@@ -0,0 +1,150 @@
+//go:build rp2040 && !bouncer_nofilter
+
+package bouncer
+
+import (
+	"device/rp"
+	"errors"
+	"runtime/interrupt"
+	"runtime/volatile"
+	"time"
+	"unsafe"
+
+	"machine"
+)
+
+// filterProgram is a PIO program which follows a pin's level, pushing 0 to the RX FIFO once the pin has been
+// low for a whole hold period and all-ones once it has been high for one. Any change during a hold restarts it.
+// A hold is 32 iterations of a 33-cycle loop
+//
+//	0: wait 0 pin 0        ; await low
+//	1: set x, 31
+//	2: jmp pin 0           ; bounced high; start over
+//	3: jmp x-- 2 [31]
+//	4: mov isr, null
+//	5: push noblock        ; settled low
+//	6: wait 1 pin 0        ; await high
+//	7: set x, 31
+//	8: jmp pin 10          ; still high
+//	9: jmp 6               ; bounced low; start over
+//	10: jmp x-- 8 [31]
+//	11: mov isr, ~null
+//	12: push noblock       ; settled high
+var filterProgram = [...]uint16{
+	0x2020, 0xe03f, 0x00c0, 0x1f42, 0xa0c3, 0x8000,
+	0x20a0, 0xe03f, 0x00ca, 0x0006, 0x1f48, 0xa0cb, 0x8000,
+}
+
+const (
+	filterHoldCycles = 32 * 33 // PIO cycles in one hold period at a clock divider of 1
+	filterSMs        = 4       // state machines in PIO1, one per filtered pin
+	smStride         = 0x18    // bytes between one state machine's registers and the next's
+)
+
+var (
+	filterLoaded bool
+	filterUsed   int                        // state machines claimed so far
+	filterEmit   [filterSMs]func(high bool) // per state machine, called from the PIO1 interrupt
+)
+
+// attachFilters gives each of the bouncer's pins a PIO1 state machine running filterProgram with a hold period of d,
+// and emits the bouncer's combined level from the PIO1 interrupt whenever one of them settles
+func (b *bouncer) attachFilters(d time.Duration, emit func(up bool)) error {
+	if b.filterSM == 0 && filterUsed+len(b.pins) > filterSMs {
+		return errors.New(ERROR_FILTER_EXHAUSTED)
+	}
+	div := uint64(machine.CPUFrequency()) * uint64(d) / uint64(time.Second) / filterHoldCycles
+	if div < 1 {
+		div = 1
+	}
+	if div > 0xffff {
+		return errors.New(ERROR_FILTER_TOO_LONG)
+	}
+	if !filterLoaded {
+		loadFilterProgram()
+	}
+	if b.filterSM == 0 { // claim state machines the first time; configured again, it reuses them
+		b.filterSM = filterUsed + 1
+		filterUsed += len(b.pins)
+	}
+	base := b.filterSM - 1
+	for i, p := range b.pins {
+		i := i
+		if p.Get() == b.activeHigh {
+			b.filteredDown |= 1 << i
+		}
+		sm := base + i
+		filterEmit[sm] = func(high bool) {
+			if high != b.activeHigh { // released
+				b.filteredDown &^= 1 << i
+			} else {
+				b.filteredDown |= 1 << i
+			}
+			if b.combine == AllDown {
+				emit(b.filteredDown != 1<<len(b.pins)-1)
+				return
+			}
+			emit(b.filteredDown == 0)
+		}
+		startFilter(sm, p, uint32(div))
+	}
+	return nil
+}
+
+// detachFilters stops the bouncer's state machines, keeping them for when it's attached again
+func (b *bouncer) detachFilters() {
+	if b.filterSM == 0 {
+		return
+	}
+	for i := range b.pins {
+		sm := uint32(b.filterSM - 1 + i)
+		rp.PIO1.CTRL.ClearBits(1 << (rp.PIO_CTRL_SM_ENABLE_Pos + sm))
+		rp.PIO1.IRQ0_INTE.ClearBits(1 << (rp.PIO_IRQ0_INTE_SM0_RXNEMPTY_Pos + sm))
+		filterEmit[sm] = nil
+	}
+	b.filteredDown = 0
+}
+
+// loadFilterProgram brings PIO1 out of reset, copies filterProgram to the start of its instruction memory,
+// and enables its interrupt
+func loadFilterProgram() {
+	rp.RESETS.RESET.ClearBits(rp.RESETS_RESET_PIO1)
+	for !rp.RESETS.RESET_DONE.HasBits(rp.RESETS_RESET_DONE_PIO1) {
+	}
+	for i, instr := range filterProgram {
+		pioRegister(unsafe.Pointer(&rp.PIO1.INSTR_MEM0), 4*uintptr(i)).Set(uint32(instr))
+	}
+	intr := interrupt.New(rp.IRQ_PIO1_IRQ_0, handleFilterInterrupt)
+	intr.Enable()
+	filterLoaded = true
+}
+
+// startFilter configures & enables state machine sm to filter pin p
+func startFilter(sm int, p machine.Pin, div uint32) {
+	off := smStride * uintptr(sm)
+	pioRegister(unsafe.Pointer(&rp.PIO1.SM0_CLKDIV), off).Set(div << rp.PIO_SM0_CLKDIV_INT_Pos)
+	pioRegister(unsafe.Pointer(&rp.PIO1.SM0_EXECCTRL), off).Set(
+		uint32(p)<<rp.PIO_SM0_EXECCTRL_JMP_PIN_Pos |
+			uint32(len(filterProgram)-1)<<rp.PIO_SM0_EXECCTRL_WRAP_TOP_Pos)
+	pioRegister(unsafe.Pointer(&rp.PIO1.SM0_PINCTRL), off).Set(uint32(p) << rp.PIO_SM0_PINCTRL_IN_BASE_Pos)
+	pioRegister(unsafe.Pointer(&rp.PIO1.SM0_INSTR), off).Set(0x0000) // jmp 0
+	rp.PIO1.IRQ0_INTE.SetBits(1 << (rp.PIO_IRQ0_INTE_SM0_RXNEMPTY_Pos + uint32(sm)))
+	rp.PIO1.CTRL.SetBits(1 << (rp.PIO_CTRL_SM_ENABLE_Pos + uint32(sm)))
+}
+
+// handleFilterInterrupt drains every state machine's RX FIFO, emitting each settled level
+func handleFilterInterrupt(interrupt.Interrupt) {
+	for sm := 0; sm < filterUsed; sm++ {
+		for !rp.PIO1.FSTAT.HasBits(1 << (rp.PIO_FSTAT_RXEMPTY_Pos + uint32(sm))) {
+			v := pioRegister(unsafe.Pointer(&rp.PIO1.RXF0), 4*uintptr(sm)).Get()
+			if filterEmit[sm] != nil {
+				filterEmit[sm](v != 0)
+			}
+		}
+	}
+}
+
+// pioRegister returns the register at offset bytes past base
+func pioRegister(base unsafe.Pointer, offset uintptr) *volatile.Register32 {
+	return (*volatile.Register32)(unsafe.Pointer(uintptr(base) + offset))
+}
@@ -0,0 +1,63 @@
+//go:build !bouncer_nogestures
+
+package bouncer
+
+// gesture is a compound gesture: taps ShortPresses, each within the click window of the last,
+// followed by a press held to Long
+type gesture struct {
+	taps  int
+	event PressLength
+}
+
+// gestureTable lists every compound gesture the recognizer knows, each enabled by a Config field;
+// a new gesture needs only an entry here and a case in enabledGestures
+var gestureTable = [...]gesture{
+	{taps: 1, event: TapHold},
+	{taps: 2, event: DoubleTapHold},
+}
+
+// enabledGestures returns the gestures a config enables
+func enabledGestures(cfg Config) ([]gesture, error) {
+	var gs []gesture
+	for _, g := range gestureTable {
+		var on bool
+		switch g.event {
+		case TapHold:
+			on = cfg.TapHold
+		case DoubleTapHold:
+			on = cfg.DoubleTapHold
+		}
+		if on {
+			gs = append(gs, g)
+		}
+	}
+	return gs, nil
+}
+
+// gestureFollows reports whether an enabled gesture could still complete after n withheld taps
+func (b *bouncer) gestureFollows(n int) bool {
+	for _, g := range b.gestures {
+		if g.taps >= n {
+			return true
+		}
+	}
+	return false
+}
+
+// gestureTick publishes a gesture once the press following its taps has been held to Long
+func (b *bouncer) gestureTick() {
+	if b.clicks == 0 || b.gestured || b.stuck || b.get() {
+		return
+	}
+	if b.heldTier() < LongPress {
+		return
+	}
+	for _, g := range b.gestures {
+		if g.taps == b.clicks {
+			b.clicks = 0 // the taps are part of the gesture
+			b.gestured = true
+			b.publish(g.event)
+			return
+		}
+	}
+}
@@ -0,0 +1,24 @@
+//go:build bouncer_nogestures
+
+package bouncer
+
+import "errors"
+
+// gesture is empty; the compound gestures are compiled out by the bouncer_nogestures tag
+type gesture struct{}
+
+// enabledGestures refuses a config which enables a gesture, as none can be recognized in this build
+func enabledGestures(cfg Config) ([]gesture, error) {
+	if cfg.TapHold || cfg.DoubleTapHold {
+		return nil, errors.New(ERROR_GESTURES_COMPILED_OUT)
+	}
+	return nil, nil
+}
+
+// gestureFollows reports that no gesture can follow, there being none
+func (b *bouncer) gestureFollows(n int) bool {
+	return false
+}
+
+// gestureTick has no gestures to publish
+func (b *bouncer) gestureTick() {}
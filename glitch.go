@@ -0,0 +1,20 @@
+package bouncer
+
+// glitchEdge holds an edge back for the glitch window, discarding it along with the edge it's holding
+// if the two are opposite and too close together to be anything but interference
+func (b *bouncer) glitchEdge(e Edge) {
+	if b.glitchHeld {
+		if e.Up != b.glitchPending.Up && e.Time.Sub(b.glitchPending.Time) < b.glitch { // a phantom pair
+			b.glitchHeld = false
+			return
+		}
+		b.releaseGlitch()
+	}
+	b.glitchPending, b.glitchHeld = e, true
+}
+
+// releaseGlitch passes the held edge on to the recognizer
+func (b *bouncer) releaseGlitch() {
+	b.glitchHeld = false
+	b.recognizeEdge(b.glitchPending)
+}
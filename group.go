@@ -0,0 +1,56 @@
+package bouncer
+
+import (
+	"errors"
+
+	"machine"
+)
+
+type group struct {
+	bouncers []*bouncer
+}
+
+// Group is a set of buttons, one bouncer per pin, publishing every Event on a single channel
+type Group interface {
+	Configure(Config) error
+	Bouncers() []Bouncer
+}
+
+// NewGroup returns a Group (or error) of one bouncer per pin, each publishing its Events, tagged with its pin,
+// on out. Group members are recognized by the package's shared goroutine, so the fan-in costs neither a channel
+// nor a goroutine per button: after Configure, start RecognizeShared once (it serves any other Shared bouncers too)
+func NewGroup(pins []machine.Pin, out chan<- Event) (Group, error) {
+	if len(pins) < 1 {
+		return nil, errors.New(ERROR_NO_PINS)
+	}
+	if out == nil {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	g := &group{}
+	for _, p := range pins {
+		b := newBouncer([]machine.Pin{p}, nil)
+		b.eventChans = []chan<- Event{out}
+		g.bouncers = append(g.bouncers, b)
+	}
+	return g, nil
+}
+
+// Configure configures every member of the group alike, as Shared bouncers, stopping at the first error
+func (g *group) Configure(cfg Config) error {
+	cfg.Shared = true
+	for _, b := range g.bouncers {
+		if err := b.Configure(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bouncers returns the group's members, in the order of their pins, for per-button settings like Handle
+func (g *group) Bouncers() []Bouncer {
+	bs := make([]Bouncer, len(g.bouncers))
+	for i, b := range g.bouncers {
+		bs[i] = b
+	}
+	return bs
+}
@@ -0,0 +1,26 @@
+package bouncer
+
+// Handle registers f to be called whenever the bouncer publishes p, replacing any handler already registered
+// for p (nil removes it). Handlers are called by the package's dispatcher after the bouncer's channels are sent
+// to, so simple applications need neither channels nor goroutines of their own; keep them quick, as every
+// bouncer's deliveries wait on them. Register before starting RecognizeAndPublish
+func (b *bouncer) Handle(p PressLength, f func()) {
+	if int(p) < len(b.handlers) {
+		b.handlers[p] = f
+	}
+}
+
+// OnShort calls f for every ShortPress; see Handle
+func (b *bouncer) OnShort(f func()) {
+	b.Handle(ShortPress, f)
+}
+
+// OnLong calls f for every LongPress; see Handle
+func (b *bouncer) OnLong(f func()) {
+	b.Handle(LongPress, f)
+}
+
+// OnExtraLong calls f for every ExtraLongPress; see Handle
+func (b *bouncer) OnExtraLong(f func()) {
+	b.Handle(ExtraLongPress, f)
+}
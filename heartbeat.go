@@ -0,0 +1,36 @@
+package bouncer
+
+import "sync/atomic"
+
+// heartbeatTick publishes the debounced state once each Heartbeat interval, whether or not it has changed
+func (b *bouncer) heartbeatTick() {
+	if b.heartbeat == 0 {
+		return
+	}
+	now := clockNow()
+	if b.beatAt.IsZero() || now.Sub(b.beatAt) >= 2*b.heartbeat { // first beat, or the ticks stopped for a while
+		b.beatAt = now
+	} else if now.Sub(b.beatAt) >= b.heartbeat {
+		b.beatAt = b.beatAt.Add(b.heartbeat) // keep to the schedule rather than drifting by a tick each beat
+	} else {
+		return
+	}
+	if b.debouncedDown() {
+		b.publish(Held)
+	} else {
+		b.publish(Released)
+	}
+}
+
+// debouncedDown reports the button's debounced state: a maintained switch's position, or for momentary buttons
+// whether the press in progress has outlasted the debounce with the pin still down, until its release is
+// recognized. An e-stop is down while its fault is latched. It's safe to call from any goroutine
+func (b *bouncer) debouncedDown() bool {
+	if b.maintained() {
+		return !b.State()
+	}
+	if b.mode == EStopMode {
+		return b.Faulted()
+	}
+	return atomic.LoadUint32(&b.down) == 1
+}
@@ -0,0 +1,34 @@
+// hid maps bouncer events to USB HID keystrokes using TinyGo's USB HID keyboard,
+// for macro pads and other keyboard-like devices.
+package hid
+
+import (
+	"machine/usb/hid/keyboard"
+
+	"github.com/eyelight/bouncer"
+)
+
+// Keymap is a bouncer's table of keystrokes, one per PressLength; PressLengths missing from the table are ignored
+type Keymap map[bouncer.PressLength]keyboard.Keycode
+
+// Run should be a goroutine; it presses & releases the mapped key for each PressLength received on ch,
+// which should be one of a bouncer's output channels
+func Run(ch <-chan bouncer.PressLength, km Keymap) {
+	kb := keyboard.Port()
+	for p := range ch {
+		if k, ok := km[p]; ok {
+			kb.Press(k)
+		}
+	}
+}
+
+// RunEvents should be a goroutine; it does the same as Run for Events received from the event bus, looking up
+// each Event's Keymap by the Name of the bouncer which published it, so one goroutine serves every button
+func RunEvents(ch <-chan bouncer.Event, tables map[string]Keymap) {
+	kb := keyboard.Port()
+	for e := range ch {
+		if k, ok := tables[e.Name][e.Length]; ok {
+			kb.Press(k)
+		}
+	}
+}
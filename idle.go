@@ -0,0 +1,49 @@
+package bouncer
+
+import "sync/atomic"
+
+var (
+	listeningBouncers int32           // count of bouncers currently needing ticks
+	idleHook          func(idle bool) // called when listeningBouncers reaches or leaves zero
+)
+
+// OnIdle registers f to be called with true when every bouncer has gone idle and no longer needs ticks,
+// and with false as soon as any bouncer needs them again. Applications may use this to slow or stop
+// the systick while idle; an edge on any interrupt-driven bouncer calls f(false) before ticks are needed.
+// Ring-buffered & EdgeFlag bouncers poll on ticks, so they never go idle
+func OnIdle(f func(idle bool)) {
+	idleHook = f
+}
+
+// needsTicks reports whether the recognizer has anything to do on a tick
+func (b *bouncer) needsTicks() bool {
+	return (b.ticks > 0 && !(b.stuck && b.stuckIdle)) || b.clicks > 0 || b.isrRing != nil || b.edgeFlag != nil || len(b.sensePins) > 0 || b.polled || b.glitchHeld || b.ledSteps > 0 || !b.coolUntil.IsZero() || b.heartbeat > 0 || (b.idleAfter > 0 && !b.inactive && !b.maintained()) || atomic.LoadUint32(&b.rearm) == 1 || b.awaitingPolledEdge()
+}
+
+// awaitingPolledEdge reports whether the pin's next edge raises no interrupt, so must be polled for
+func (b *bouncer) awaitingPolledEdge() bool {
+	low := b.levelUp == b.activeHigh // released pins sit high if active low, low if active high
+	return (b.edgeScheme == FallingEdges && low) || (b.edgeScheme == RisingEdges && !low)
+}
+
+// setListening subscribes the bouncer to relayed ticks (or not)
+func (b *bouncer) setListening(on bool) {
+	if !on {
+		b.tickMeter.pause()
+	}
+	listen(&b.listening, on)
+}
+
+// listen sets a tick subscriber's listening flag, notifying the idle hook
+// when the package as a whole changes between idle and busy
+func listen(listening *uint32, on bool) {
+	if on {
+		if atomic.CompareAndSwapUint32(listening, 0, 1) && atomic.AddInt32(&listeningBouncers, 1) == 1 && idleHook != nil {
+			idleHook(false)
+		}
+		return
+	}
+	if atomic.CompareAndSwapUint32(listening, 1, 0) && atomic.AddInt32(&listeningBouncers, -1) == 0 && idleHook != nil {
+		idleHook(true)
+	}
+}
@@ -0,0 +1,21 @@
+package bouncer
+
+// inactivityTick publishes Idle once a momentary button has gone IdleAfter without a press, and Active as soon
+// as the next press has been debounced
+func (b *bouncer) inactivityTick() {
+	if b.idleAfter == 0 || b.maintained() {
+		return
+	}
+	if b.debouncedDown() {
+		b.activeAt = clockNow()
+		if b.inactive {
+			b.inactive = false
+			b.publish(Active)
+		}
+		return
+	}
+	if !b.inactive && clockSince(b.activeAt) >= b.idleAfter {
+		b.inactive = true
+		b.publish(Idle)
+	}
+}
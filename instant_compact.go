@@ -0,0 +1,72 @@
+//go:build bouncer_compact
+
+package bouncer
+
+import "time"
+
+// Instant is a timestamp on the package clock in microseconds, 4 bytes in place of time.Time's 24 & with 32-bit
+// math for Cortex-M0 and the like. It wraps around every 71 minutes, so it can only measure spans of up to
+// half that (about 35 minutes); zero means unset
+type Instant uint32
+
+// epoch is the package clock's reading when the package started (or SetClock was last called), from which
+// Instants count
+var epoch = clock.Now()
+
+// micros reads the package clock's microsecond counter, if it's a MicrosClock
+var micros func() uint32
+
+// clockChanged restarts the count from the new clock's reading
+func clockChanged() {
+	epoch = clock.Now()
+	micros = nil
+	if mc, ok := clock.(MicrosClock); ok {
+		micros = mc.Micros
+	}
+}
+
+// instantOf converts a reading of the package clock to an Instant, never zero
+func instantOf(t time.Time) Instant {
+	return nonZero(Instant(t.Sub(epoch) / time.Microsecond))
+}
+
+// nonZero nudges i off zero, which means unset
+func nonZero(i Instant) Instant {
+	if i == 0 {
+		i = 1
+	}
+	return i
+}
+
+// clockNow reads the package clock; a MicrosClock's counter is taken as it is, in 32 bits
+func clockNow() Instant {
+	if micros != nil {
+		return nonZero(Instant(micros()))
+	}
+	return instantOf(clock.Now())
+}
+
+// Sub returns the span i-j
+func (i Instant) Sub(j Instant) time.Duration {
+	return time.Duration(int32(i-j)) * time.Microsecond
+}
+
+// Add returns i+d
+func (i Instant) Add(d time.Duration) Instant {
+	return i + Instant(d/time.Microsecond)
+}
+
+// IsZero reports whether i is unset
+func (i Instant) IsZero() bool {
+	return i == 0
+}
+
+// Before reports whether i is earlier than j
+func (i Instant) Before(j Instant) bool {
+	return int32(i-j) < 0
+}
+
+// After reports whether i is later than j
+func (i Instant) After(j Instant) bool {
+	return int32(i-j) > 0
+}
@@ -0,0 +1,16 @@
+//go:build !bouncer_compact
+
+package bouncer
+
+import "time"
+
+// Instant is a timestamp on the package clock. It's a time.Time, unless built with the bouncer_compact tag
+type Instant = time.Time
+
+// clockChanged has nothing to do; time.Time carries its own epoch
+func clockChanged() {}
+
+// clockNow reads the package clock
+func clockNow() Instant {
+	return clock.Now()
+}
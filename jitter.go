@@ -0,0 +1,77 @@
+//go:build !bouncer_nostats
+
+package bouncer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// tickMeter measures the intervals between a recognizer's ticks. Durations are kept in microseconds
+// and set atomically, so TickStats can read them from another goroutine
+type tickMeter struct {
+	last        Instant       // the previous tick; zero if the recognizer has stopped listening since
+	gap         time.Duration // see Config.TickGap
+	ticks       uint32
+	mean        uint32
+	jitter      uint32
+	maxJitter   uint32
+	maxInterval uint32
+	gaps        uint32
+}
+
+// tick measures the interval since the previous tick
+func (m *tickMeter) tick(now Instant) {
+	last := m.last
+	m.last = now
+	if last.IsZero() {
+		return
+	}
+	us := uint32(now.Sub(last) / time.Microsecond)
+	mean := atomic.LoadUint32(&m.mean)
+	if atomic.AddUint32(&m.ticks, 1) == 1 {
+		atomic.StoreUint32(&m.mean, us)
+		atomic.StoreUint32(&m.maxInterval, us)
+		return
+	}
+	dev := us - mean
+	if us < mean {
+		dev = mean - us
+	}
+	jitter := atomic.LoadUint32(&m.jitter)
+	atomic.StoreUint32(&m.jitter, uint32(int32(jitter)+(int32(dev)-int32(jitter))/8))
+	atomic.StoreUint32(&m.mean, uint32(int32(mean)+(int32(us)-int32(mean))/8))
+	if dev > atomic.LoadUint32(&m.maxJitter) {
+		atomic.StoreUint32(&m.maxJitter, dev)
+	}
+	if us > atomic.LoadUint32(&m.maxInterval) {
+		atomic.StoreUint32(&m.maxInterval, us)
+	}
+	gap := uint32(m.gap / time.Microsecond)
+	if gap == 0 {
+		gap = 2 * mean
+	}
+	if us > gap {
+		atomic.AddUint32(&m.gaps, 1)
+	}
+}
+
+// pause forgets the previous tick, so the time spent idle isn't measured as a gap
+func (m *tickMeter) pause() {
+	m.last = never
+}
+
+// TickStats returns how regularly the bouncer has received ticks. Only ticks taken back to back while
+// listening are measured; an idle bouncer isn't sent ticks, and that isn't starvation
+func (b *bouncer) TickStats() TickStats {
+	us := func(v *uint32) time.Duration { return time.Duration(atomic.LoadUint32(v)) * time.Microsecond }
+	m := &b.tickMeter
+	return TickStats{
+		Ticks:        atomic.LoadUint32(&m.ticks),
+		MeanInterval: us(&m.mean),
+		MeanJitter:   us(&m.jitter),
+		MaxJitter:    us(&m.maxJitter),
+		MaxInterval:  us(&m.maxInterval),
+		Gaps:         atomic.LoadUint32(&m.gaps),
+	}
+}
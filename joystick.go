@@ -0,0 +1,160 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"machine"
+)
+
+// Direction is a bitmask of the contacts of a 5-way joystick; diagonals are two directions OR'd together
+type Direction uint8
+
+const (
+	Up Direction = 1 << iota
+	Down
+	Left
+	Right
+	Center
+
+	UpLeft    = Up | Left
+	UpRight   = Up | Right
+	DownLeft  = Down | Left
+	DownRight = Down | Right
+)
+
+// JoystickEvent is published by a Joystick when it returns to rest
+type JoystickEvent struct {
+	Direction Direction
+	Length    PressLength
+}
+
+type joystick struct {
+	pins           [5]machine.Pin // indexed by bit position of Direction
+	shortPress     time.Duration
+	longPress      time.Duration
+	extraLongPress time.Duration
+	tickerCh       chan struct{}          // produced by sendTicks -> consumed by RecognizeAndPublish
+	done           chan struct{}          // closed by Shutdown, ending RecognizeAndPublish
+	stopped        uint32                 // set once Shutdown has stopped the device
+	outChans       []chan<- JoystickEvent // receive an event each time the stick is released
+	raw            Direction              // mask sampled on the previous tick
+	stable         Direction              // debounced mask
+	held           uint32                 // copy of stable, set atomically so Direction can read it
+	peak           Direction              // widest debounced mask during the current press
+	btnDown        Instant                // beginning of the current press
+	listening      uint32                 // joysticks are polled, so they always listen for ticks
+}
+
+// Joystick is a 5-way tactile joystick (hat) debounced as one device on a single tick subscription
+type Joystick interface {
+	Configure(Config) error
+	RecognizeAndPublish()
+	Direction() Direction
+}
+
+// NewJoystick returns a new Joystick (or error) with the given direction pins & channels, with the same
+// default durations as New
+func NewJoystick(up, down, left, right, center machine.Pin, outs ...chan<- JoystickEvent) (Joystick, error) {
+	if len(outs) < 1 {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	return &joystick{
+		pins:           [5]machine.Pin{up, down, left, right, center},
+		shortPress:     22 * time.Millisecond,
+		longPress:      500 * time.Millisecond,
+		extraLongPress: 1971 * time.Millisecond,
+		tickerCh:       make(chan struct{}, 1),
+		done:           make(chan struct{}),
+		outChans:       outs,
+	}, nil
+}
+
+// Configure validates the config, sets the pins to InputPullup, overrides default durations, and subscribes the joystick to ticks
+func (j *joystick) Configure(cfg Config) error {
+	short, long, extraLong := mergeDurations(cfg, j.shortPress, j.longPress, j.extraLongPress)
+	if err := validate(cfg, short, long, extraLong); err != nil {
+		return err
+	}
+	for _, p := range j.pins {
+		if !cfg.SkipPinConfigure {
+			p.Configure(machine.PinConfig{Mode: machine.PinInputPullup})
+		}
+	}
+	j.shortPress, j.longPress, j.extraLongPress = short, long, extraLong
+	addSysTickConsumer(j.tickerCh, &j.listening)
+	listen(&j.listening, true)
+	devices.add(j)
+	return nil
+}
+
+// Direction returns the debounced direction currently held, or zero at rest
+func (j *joystick) Direction() Direction {
+	return Direction(atomic.LoadUint32(&j.held))
+}
+
+// RecognizeAndPublish should be a goroutine; samples every contact on each tick, accepting a new mask once it has
+// been read twice in a row. When the stick returns to rest, the widest direction held (so a diagonal beats either
+// of its halves) is published with the press length
+func (j *joystick) RecognizeAndPublish() {
+	for {
+		select {
+		case <-j.tickerCh:
+		case <-j.done:
+			return
+		}
+		raw := j.sample()
+		if raw != j.raw { // still moving
+			j.raw = raw
+			continue
+		}
+		if raw == j.stable {
+			continue
+		}
+		if j.stable == 0 { // a press begins
+			j.btnDown = clockNow()
+			j.peak = 0
+		}
+		j.stable = raw
+		atomic.StoreUint32(&j.held, uint32(raw))
+		if bits(raw) > bits(j.peak) {
+			j.peak = raw
+		}
+		if raw != 0 {
+			continue
+		}
+		e := JoystickEvent{
+			Direction: j.peak,
+			Length:    classify(clockSince(j.btnDown), j.shortPress, j.longPress, j.extraLongPress),
+		}
+		for _, ch := range j.outChans {
+			ch <- e
+		}
+	}
+}
+
+// sample reads the contacts into a mask
+func (j *joystick) sample() Direction {
+	var d Direction
+	for i, p := range j.pins {
+		if !p.Get() {
+			d |= 1 << i
+		}
+	}
+	return d
+}
+
+// bits counts the directions in a mask
+func bits(d Direction) int {
+	n := 0
+	for ; d != 0; d &= d - 1 {
+		n++
+	}
+	return n
+}
+
+// stop ends RecognizeAndPublish, for Shutdown
+func (j *joystick) stop() {
+	halt(j.tickerCh, j.done, &j.listening, &j.stopped)
+}
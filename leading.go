@@ -0,0 +1,32 @@
+package bouncer
+
+import "sync/atomic"
+
+// leadTick publishes ShortPress once a held press is debounced, then each longer tier as the hold reaches it
+func (b *bouncer) leadTick() {
+	if b.stuck || atomic.LoadUint32(&b.down) == 0 || b.get() { // not (yet) a debounced press
+		return
+	}
+	if b.leadTier == Bounce && b.cooling(clockNow()) { // pressed again within the cooldown
+		return
+	}
+	tier := b.heldTier()
+	if tier < ShortPress {
+		tier = ShortPress
+	}
+	if tier > b.leadTier {
+		b.leadTier = tier
+		b.publish(tier)
+	}
+}
+
+// heldTier returns the PressLength the press in progress would be recognized as if released now
+func (b *bouncer) heldTier() PressLength {
+	if b.btnDown.IsZero() { // no press in progress
+		return Bounce
+	}
+	if b.shortTicks > 0 {
+		return b.recognizeTicks(b.ticks - 1)
+	}
+	return b.recognize(clockSince(b.btnDown))
+}
@@ -0,0 +1,63 @@
+package bouncer
+
+import (
+	"time"
+
+	"machine"
+)
+
+// ledBlinkPeriod is the on (and off) time of each feedback blink
+const ledBlinkPeriod = 120 * time.Millisecond
+
+var defaultLEDBlinks = map[PressLength]int{ShortPress: 1, LongPress: 2, ExtraLongPress: 3}
+
+// configureLED sets up the feedback LED from the config, if there is one
+func (b *bouncer) configureLED(cfg Config) {
+	if b.hasLED { // configuring again: put out the previous LED, which may not be used any more
+		b.led.Low()
+		b.hasLED, b.ledSteps = false, 0
+	}
+	if cfg.LED == nil {
+		return
+	}
+	b.led = *cfg.LED
+	b.hasLED = true
+	b.ledBlinks = cfg.LEDBlinks
+	if b.ledBlinks == nil {
+		b.ledBlinks = defaultLEDBlinks
+	}
+	b.led.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	b.led.Low()
+}
+
+// ledHold lights the LED while the button is held, cancelling any blinking
+func (b *bouncer) ledHold(on bool) {
+	if !b.hasLED {
+		return
+	}
+	b.ledSteps = 0
+	b.led.Set(on)
+}
+
+// ledPattern begins blinking the pattern for a published PressLength
+func (b *bouncer) ledPattern(p PressLength) {
+	if !b.hasLED || b.ledBlinks[p] == 0 {
+		return
+	}
+	b.ledSteps = 2 * b.ledBlinks[p]
+	b.ledNext = clockNow()
+}
+
+// ledTick advances blinking; even steps are on and odd steps off, so the pattern ends with the LED off
+func (b *bouncer) ledTick() {
+	if b.ledSteps == 0 {
+		return
+	}
+	now := clockNow()
+	if now.Before(b.ledNext) {
+		return
+	}
+	b.led.Set(b.ledSteps%2 == 0)
+	b.ledSteps -= 1
+	b.ledNext = now.Add(ledBlinkPeriod)
+}
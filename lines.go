@@ -0,0 +1,103 @@
+package bouncer
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+
+	"machine"
+)
+
+// lineOwners records which pin has claimed each external interrupt line, on targets where several pins share one.
+// Like the other registries, the map is replaced wholesale under registryMu and never modified once stored
+var lineOwners atomic.Value // map[int]machine.Pin
+
+// lineSnapshot returns the current line owners; the map mustn't be modified
+func lineSnapshot() map[int]machine.Pin {
+	owners, _ := lineOwners.Load().(map[int]machine.Pin)
+	return owners
+}
+
+// claimLines claims the interrupt lines of pins, or returns an error naming the first clash without claiming any
+func claimLines(pins []machine.Pin) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	old := lineSnapshot()
+	claiming := map[int]machine.Pin{}
+	for _, p := range pins {
+		line, ok := interruptLine(p)
+		if !ok {
+			continue
+		}
+		owner, taken := old[line]
+		if !taken {
+			owner, taken = claiming[line]
+		}
+		if taken && owner != p {
+			return errors.New(ERROR_LINE_CONFLICT + ": pin " + strconv.Itoa(int(p)) + " and pin " +
+				strconv.Itoa(int(owner)) + " both need line " + strconv.Itoa(line))
+		}
+		claiming[line] = p
+	}
+	if len(claiming) == 0 {
+		return nil
+	}
+	owners := make(map[int]machine.Pin, len(old)+len(claiming))
+	for line, p := range old {
+		owners[line] = p
+	}
+	for line, p := range claiming {
+		owners[line] = p
+	}
+	lineOwners.Store(owners)
+	return nil
+}
+
+// releaseLines gives up the interrupt lines claimed by pins
+func releaseLines(pins []machine.Pin) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	old := lineSnapshot()
+	owners := make(map[int]machine.Pin, len(old))
+	for line, p := range old {
+		owners[line] = p
+	}
+	for _, p := range pins {
+		if line, ok := interruptLine(p); ok && owners[line] == p {
+			delete(owners, line)
+		}
+	}
+	lineOwners.Store(owners)
+}
+
+// LineConflict reports the already-configured pin, if any, whose external interrupt line p would clash with
+func LineConflict(p machine.Pin) (machine.Pin, bool) {
+	line, ok := interruptLine(p)
+	if !ok {
+		return machine.NoPin, false
+	}
+	owner, taken := lineSnapshot()[line]
+	return owner, taken && owner != p
+}
+
+// CompatiblePins returns those candidates which can be configured together, in order, skipping any whose
+// external interrupt line is taken by an earlier candidate or an already-configured pin. Use it at startup
+// to pick a safe set of pins from those a board offers
+func CompatiblePins(candidates []machine.Pin) []machine.Pin {
+	used := map[int]bool{}
+	for line := range lineSnapshot() {
+		used[line] = true
+	}
+	var safe []machine.Pin
+	for _, p := range candidates {
+		line, ok := interruptLine(p)
+		if ok && used[line] {
+			continue
+		}
+		if ok {
+			used[line] = true
+		}
+		safe = append(safe, p)
+	}
+	return safe
+}
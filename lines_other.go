@@ -0,0 +1,10 @@
+//go:build !(atsamd21 || atsamd51 || atsame5x || stm32)
+
+package bouncer
+
+import "machine"
+
+// interruptLine reports that pins don't share interrupt lines on this target
+func interruptLine(p machine.Pin) (int, bool) {
+	return 0, false
+}
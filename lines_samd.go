@@ -0,0 +1,11 @@
+//go:build atsamd51 || atsame5x
+
+package bouncer
+
+import "machine"
+
+// interruptLine returns the EIC EXTINT line of a pin; pins are numbered 32 to a port and
+// each port's pins n and n+16 share line n
+func interruptLine(p machine.Pin) (int, bool) {
+	return int(p) % 16, true
+}
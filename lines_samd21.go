@@ -0,0 +1,28 @@
+//go:build atsamd21
+
+package bouncer
+
+import "machine"
+
+// interruptLine returns the EIC EXTINT line of a pin, following the same table as the target's SetInterrupt:
+// most pins n and n+16 of a port share line n, but a few PA pins are wired to other lines, and PA08 only
+// reaches the NMI, which SetInterrupt doesn't support, so it claims no line
+func interruptLine(p machine.Pin) (int, bool) {
+	switch p {
+	case machine.PA08:
+		return 0, false
+	case machine.PA24:
+		return 12, true
+	case machine.PA25:
+		return 13, true
+	case machine.PA27:
+		return 15, true
+	case machine.PA28:
+		return 8, true
+	case machine.PA30:
+		return 10, true
+	case machine.PA31:
+		return 11, true
+	}
+	return int(p) % 16, true
+}
@@ -0,0 +1,11 @@
+//go:build stm32
+
+package bouncer
+
+import "machine"
+
+// interruptLine returns the EXTI line of a pin; pins are numbered 16 to a port and
+// pin n of every port shares line n
+func interruptLine(p machine.Pin) (int, bool) {
+	return int(p) % 16, true
+}
@@ -0,0 +1,87 @@
+// menu maps bouncer events to menu navigation verbs, and tracks a cursor through a menu tree as they arrive,
+// so single-button projects needn't reimplement the same navigation each time.
+package menu
+
+import "github.com/eyelight/bouncer"
+
+// Verb is a menu navigation action
+type Verb uint8
+
+const (
+	None     Verb = iota // the event isn't bound
+	Next                 // highlight the next item, wrapping around
+	Previous             // highlight the previous item, wrapping around
+	Select               // enter the highlighted submenu, or activate the highlighted item
+	Back                 // return to the parent menu
+	Home                 // return to the top of the menu
+)
+
+// Map binds PressLengths to Verbs; PressLengths missing from the map are ignored
+type Map map[bouncer.PressLength]Verb
+
+// SingleButton is the usual binding for a menu driven by one button
+var SingleButton = Map{
+	bouncer.ShortPress:     Next,
+	bouncer.DoubleClick:    Back,
+	bouncer.LongPress:      Select,
+	bouncer.ExtraLongPress: Home,
+}
+
+// Run should be a goroutine; it sends the Verb bound to each PressLength received on ch,
+// which should be one of a bouncer's output channels, to verbs
+func Run(ch <-chan bouncer.PressLength, m Map, verbs chan<- Verb) {
+	for p := range ch {
+		if v, ok := m[p]; ok && v != None {
+			verbs <- v
+		}
+	}
+}
+
+// RunEvents should be a goroutine; it does the same as Run for Events received from the event bus, looking up
+// each Event's Map by the Name of the bouncer which published it, so several buttons can drive one menu
+func RunEvents(ch <-chan bouncer.Event, maps map[string]Map, verbs chan<- Verb) {
+	for e := range ch {
+		if v, ok := maps[e.Name][e.Length]; ok && v != None {
+			verbs <- v
+		}
+	}
+}
+
+// Cursor is the navigation state machine: the index of the highlighted item at each level of the menu,
+// from the top down. Its zero value highlights the first item of the top menu
+type Cursor struct {
+	Path []int
+}
+
+// Apply moves the cursor by one Verb. size returns the number of items in the submenu at path
+// (an empty path being the top menu), or zero if the item at path is an action rather than a submenu.
+// Apply returns true when Select activates an action, whose path is then c.Path
+func (c *Cursor) Apply(v Verb, size func(path []int) int) bool {
+	if len(c.Path) == 0 {
+		c.Path = append(c.Path, 0)
+	}
+	last := len(c.Path) - 1
+	n := size(c.Path[:last])
+	switch v {
+	case Next:
+		if n > 0 {
+			c.Path[last] = (c.Path[last] + 1) % n
+		}
+	case Previous:
+		if n > 0 {
+			c.Path[last] = (c.Path[last] + n - 1) % n
+		}
+	case Select:
+		if size(c.Path) == 0 {
+			return true
+		}
+		c.Path = append(c.Path, 0)
+	case Back:
+		if last > 0 {
+			c.Path = c.Path[:last]
+		}
+	case Home:
+		c.Path = append(c.Path[:0], 0)
+	}
+	return false
+}
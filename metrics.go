@@ -0,0 +1,65 @@
+package bouncer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var (
+	droppedEdges uint32 // edges discarded because a bouncer's ring was full
+	published    uint32 // events published by every bouncer
+)
+
+// RuntimeMetrics is a snapshot of the package's runtime state, for on-device display or serialization
+type RuntimeMetrics struct {
+	Bouncers        int    // bouncers configured
+	TickSubscribers int    // everything subscribed to relayed ticks (bouncers, banks, selectors, counters...)
+	Listening       int    // tick subscribers currently taking ticks
+	QueueDepth      int    // events waiting for the dispatcher
+	QueueCapacity   int    // the most events which can wait before they're dropped
+	DroppedEdges    uint32 // edges lost to full ring buffers
+	DroppedEvents   uint32 // events lost to a full dispatch queue (or, under Update, full subscribers)
+	Published       uint32 // events published, by every bouncer
+	// LostTicks counts the ticks each tick subscriber missed because it hadn't taken the previous one,
+	// in the order they subscribed (which is the order they were configured)
+	LostTicks []uint32
+}
+
+// Metrics returns a snapshot of the package's runtime state
+func Metrics() RuntimeMetrics {
+	return RuntimeMetrics{
+		Bouncers:        len(registered.snapshot()),
+		TickSubscribers: len(tickSubscribers.snapshot()),
+		Listening:       int(atomic.LoadInt32(&listeningBouncers)),
+		QueueDepth:      len(dispatchQueue),
+		QueueCapacity:   cap(dispatchQueue),
+		DroppedEdges:    atomic.LoadUint32(&droppedEdges),
+		DroppedEvents:   atomic.LoadUint32(&droppedEvents),
+		Published:       atomic.LoadUint32(&published),
+		LostTicks:       lostTicks(),
+	}
+}
+
+// lostTicks snapshots each tick subscriber's lost tick count
+func lostTicks() []uint32 {
+	subs := tickSubscribers.snapshot()
+	lost := make([]uint32, len(subs))
+	for i, c := range subs {
+		lost[i] = atomic.LoadUint32(&c.lost)
+	}
+	return lost
+}
+
+// PressCounts holds a total for each PressLength, indexed by PressLength
+type PressCounts [len(pressLengthNames)]uint32
+
+// TickStats describes how regularly a bouncer's recognizer has received ticks while listening. Debouncing
+// counts ticks, so a starved or irregular tick feed quietly changes what counts as a bounce or a press
+type TickStats struct {
+	Ticks        uint32        // intervals measured
+	MeanInterval time.Duration // moving average of the interval between ticks
+	MeanJitter   time.Duration // moving average of each interval's distance from MeanInterval
+	MaxJitter    time.Duration // the furthest any interval has been from MeanInterval
+	MaxInterval  time.Duration // the longest interval between ticks
+	Gaps         uint32        // intervals longer than Config.TickGap (or twice MeanInterval): the feed starving
+}
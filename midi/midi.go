@@ -0,0 +1,71 @@
+// midi maps bouncer events to MIDI Note On/Off and Control Change messages,
+// written to any io.Writer such as a machine.UART or USB MIDI port.
+package midi
+
+import (
+	"io"
+
+	"github.com/eyelight/bouncer"
+)
+
+// Kind is the type of MIDI message a Binding sends
+type Kind uint8
+
+const (
+	Note          Kind = iota // Note On followed by Note Off
+	ControlChange             // a single Control Change
+)
+
+const (
+	noteOff       = 0x80
+	noteOn        = 0x90
+	controlChange = 0xB0
+)
+
+// Binding is the MIDI message sent for one PressLength
+type Binding struct {
+	Kind    Kind
+	Channel uint8 // 0-15
+	Number  uint8 // note number, or controller number for ControlChange
+	Value   uint8 // note-on velocity, or controller value for ControlChange
+}
+
+// Map is a bouncer's table of Bindings; PressLengths missing from the table are ignored
+type Map map[bouncer.PressLength]Binding
+
+// Run should be a goroutine; it writes the mapped message(s) to w for each PressLength received on ch,
+// which should be one of a bouncer's output channels
+func Run(ch <-chan bouncer.PressLength, m Map, w io.Writer) {
+	for p := range ch {
+		if bd, ok := m[p]; ok {
+			bd.write(w)
+		}
+	}
+}
+
+// RunEvents should be a goroutine; it does the same as Run for Events received from the event bus, looking up
+// each Event's Map by the Name of the bouncer which published it, so one goroutine serves every button
+func RunEvents(ch <-chan bouncer.Event, maps map[string]Map, w io.Writer) {
+	for e := range ch {
+		if bd, ok := maps[e.Name][e.Length]; ok {
+			bd.write(w)
+		}
+	}
+}
+
+// write sends the Binding's message(s) to w
+func (bd Binding) write(w io.Writer) error {
+	ch := bd.Channel & 0x0F
+	var msg [6]byte
+	n := 3
+	switch bd.Kind {
+	case ControlChange:
+		msg[0], msg[1], msg[2] = controlChange|ch, bd.Number&0x7F, bd.Value&0x7F
+	default:
+		msg[0], msg[1], msg[2] = noteOn|ch, bd.Number&0x7F, bd.Value&0x7F
+		msg[3], msg[4], msg[5] = noteOff|ch, bd.Number&0x7F, 0
+		n = 6
+	}
+	_, err := w.Write(msg[:n])
+	return err
+}
@@ -0,0 +1,37 @@
+package bouncer
+
+import "errors"
+
+type modifier struct {
+	mod *bouncer
+}
+
+// Modifier lets one bouncer act like a shift key for others
+type Modifier interface {
+	Bind(b Bouncer, alts ...chan<- PressLength) error
+}
+
+// NewModifier returns a Modifier (or error) for the given bouncer
+func NewModifier(mod Bouncer) (Modifier, error) {
+	m, ok := mod.(*bouncer)
+	if !ok {
+		return nil, errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	return &modifier{mod: m}, nil
+}
+
+// Bind routes b's events to alts instead of its usual channels for as long as the modifier is held.
+// A modifier press that modified another bouncer's event is consumed and not published itself.
+// Bind before starting b's RecognizeAndPublish
+func (m *modifier) Bind(b Bouncer, alts ...chan<- PressLength) error {
+	bb, ok := b.(*bouncer)
+	if !ok {
+		return errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	if len(alts) < 1 {
+		return errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	bb.modifier = m.mod
+	bb.altChans = alts
+	return nil
+}
@@ -0,0 +1,87 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+
+	"machine"
+)
+
+// Option configures a bouncer made by NewWith
+type Option func(*options)
+
+type options struct {
+	cfg      Config
+	outs     []chan<- PressLength
+	handlers map[PressLength]func()
+}
+
+// NewWith returns a new, configured Bouncer (or error) with the given pin and options, replacing the
+// New & Configure pair. Anything not set by an option keeps its default. It needs a subscriber or a handler
+func NewWith(p machine.Pin, opts ...Option) (Bouncer, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.build(p)
+}
+
+// build makes & configures a bouncer for the pin from the options
+func (o *options) build(p machine.Pin) (Bouncer, error) {
+	if len(o.outs) < 1 && len(o.handlers) < 1 {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	b := newBouncer([]machine.Pin{p}, o.outs)
+	for l, f := range o.handlers {
+		b.Handle(l, f)
+	}
+	if err := b.Configure(o.cfg); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WithConfig starts from a whole Config; options after it override its fields
+func WithConfig(cfg Config) Option {
+	return func(o *options) {
+		o.cfg = cfg
+	}
+}
+
+// WithDurations sets the short, long & extra long press thresholds
+func WithDurations(short, long, extraLong time.Duration) Option {
+	return func(o *options) {
+		o.cfg.Short, o.cfg.Long, o.cfg.ExtraLong = short, long, extraLong
+	}
+}
+
+// WithPolarity sets the pin level of a pressed button
+func WithPolarity(p Polarity) Option {
+	return func(o *options) {
+		o.cfg.Polarity = p
+	}
+}
+
+// WithDebounceTicks sets how many systicks must elapse between 'down' and 'up' for a press to count
+func WithDebounceTicks(n int) Option {
+	return func(o *options) {
+		o.cfg.DebounceTicks = n
+	}
+}
+
+// WithSubscriber adds an output channel; at least one (or a WithHandler) is required
+func WithSubscriber(ch chan<- PressLength) Option {
+	return func(o *options) {
+		o.outs = append(o.outs, ch)
+	}
+}
+
+// WithHandler calls f whenever the bouncer publishes l; with handlers, a bouncer needs no channels at all
+func WithHandler(l PressLength, f func()) Option {
+	return func(o *options) {
+		if o.handlers == nil {
+			o.handlers = make(map[PressLength]func())
+		}
+		o.handlers[l] = f
+	}
+}
@@ -0,0 +1,51 @@
+package bouncer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var errorChans atomic.Value // []chan<- error, receiving warnings such as OverrunError; see SubscribeErrors
+
+// errorSnapshot returns the current error channels; it mustn't be modified
+func errorSnapshot() []chan<- error {
+	chans, _ := errorChans.Load().([]chan<- error)
+	return chans
+}
+
+// SubscribeErrors adds a channel to receive the warnings recognizers raise while running, such as an
+// OverrunError when handling an edge or tick took longer than a tick period. Warnings are sent without
+// blocking, so a channel which isn't ready misses them
+func SubscribeErrors(ch chan<- error) {
+	registryMu.Lock()
+	old := errorSnapshot()
+	chans := make([]chan<- error, len(old), len(old)+1)
+	copy(chans, old)
+	errorChans.Store(append(chans, ch))
+	registryMu.Unlock()
+}
+
+// raise sends err to every error channel which can take it
+func raise(err error) {
+	for _, ch := range errorSnapshot() {
+		select {
+		case ch <- err:
+		default:
+		}
+	}
+}
+
+// checkOverrun raises an OverrunError if handling the edge or tick which began at start took longer than
+// a tick period. The period is the mean interval between ticks, so nothing is checked until it's been measured
+func (b *bouncer) checkOverrun(start Instant, tick bool) {
+	if len(errorSnapshot()) == 0 {
+		return
+	}
+	period := time.Duration(atomic.LoadUint32(&b.tickMeter.mean)) * time.Microsecond
+	if period == 0 {
+		return
+	}
+	if took := clockSince(start); took > period {
+		raise(&OverrunError{Pin: b.pins[0], Name: b.name, Tick: tick, Took: took, Period: period})
+	}
+}
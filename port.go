@@ -0,0 +1,117 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"machine"
+)
+
+// portOwner is a bouncer whose pins are batched on the port
+type portOwner struct {
+	mask       uint32        // bits of portPins belonging to the bouncer
+	activeHigh bool          // the bouncer's pins read high when pressed
+	allDown    bool          // the bouncer is down only while all its pins are (Combine AllDown)
+	emit       func(up bool) // hands the bouncer's level to its recognizer; nil once detached
+	bouncer    *bouncer
+}
+
+// portBatch is the port's batched pins & their owners. Like the other registries it's replaced wholesale under
+// registryMu and never modified once stored, so handlePort takes a consistent snapshot without locking
+type portBatch struct {
+	pins   []machine.Pin // every batched pin; bit i of a snapshot is pins[i]
+	owners []portOwner
+}
+
+var (
+	port     atomic.Value // portBatch
+	portLast uint32       // previous snapshot; a set bit is a high pin
+)
+
+// portSnapshot returns the current batch; it mustn't be modified
+func portSnapshot() portBatch {
+	pb, _ := port.Load().(portBatch)
+	return pb
+}
+
+// attachPort adds the bouncer's pins to the port batch. Each pin still takes its own interrupt, so this saves
+// no interrupt slots; they all just run handlePort
+func (b *bouncer) attachPort(emit func(up bool)) error {
+	registryMu.Lock()
+	pb := portSnapshot()
+	pins := pb.pins
+	owners := append([]portOwner(nil), pb.owners...)
+	i := pb.ownerOf(b)
+	if i < 0 { // first time: take slots for its pins
+		if len(pins)+len(b.pins) > 32 {
+			registryMu.Unlock()
+			return errors.New(ERROR_PORT_FULL)
+		}
+		pins = append(append(make([]machine.Pin, 0, len(pins)+len(b.pins)), pins...), b.pins...)
+		var mask uint32
+		for j := range b.pins {
+			mask |= 1 << (len(pb.pins) + j)
+		}
+		owners = append(owners, portOwner{mask: mask, bouncer: b})
+		i = len(owners) - 1
+	}
+	owners[i].activeHigh, owners[i].allDown, owners[i].emit = b.activeHigh, b.combine == AllDown, emit
+	portLast = readPort(pins)
+	port.Store(portBatch{pins: pins, owners: owners})
+	registryMu.Unlock()
+	for _, p := range b.pins {
+		if err := p.SetInterrupt(machine.PinFalling|machine.PinRising, handlePort); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handlePort is the interrupt callback for every batched pin. It snapshots all the batched pins and passes
+// the new level to each bouncer with a changed pin, however many pins changed together; the interrupts
+// raised by the other pins of a simultaneous change then find nothing new, and emit nothing
+func handlePort(machine.Pin) {
+	pb := portSnapshot()
+	snap := readPort(pb.pins)
+	changed := snap ^ portLast
+	portLast = snap
+	for _, o := range pb.owners {
+		if changed&o.mask == 0 || o.emit == nil {
+			continue
+		}
+		switch {
+		case o.allDown && o.activeHigh:
+			o.emit(snap&o.mask != o.mask) // up unless every one of its pins is high
+		case o.allDown:
+			o.emit(snap&o.mask != 0) // up unless every one of its pins is low
+		case o.activeHigh:
+			o.emit(snap&o.mask == 0) // up only while every one of its pins is low
+		default:
+			o.emit(snap&o.mask == o.mask) // up only while every one of its pins is high
+		}
+	}
+}
+
+// ownerOf returns the index of the bouncer's slot in the batch, or -1 if it hasn't one
+func (pb portBatch) ownerOf(b *bouncer) int {
+	for i := range pb.owners {
+		if pb.owners[i].bouncer == b {
+			return i
+		}
+	}
+	return -1
+}
+
+// detachPort stops the port batch emitting for the bouncer, keeping its slots for when it's attached again
+func detachPort(b *bouncer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	pb := portSnapshot()
+	i := pb.ownerOf(b)
+	if i < 0 {
+		return
+	}
+	owners := append([]portOwner(nil), pb.owners...)
+	owners[i].emit = nil
+	port.Store(portBatch{pins: pb.pins, owners: owners})
+}
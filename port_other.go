@@ -0,0 +1,16 @@
+//go:build !rp2040
+
+package bouncer
+
+import "machine"
+
+// readPort snapshots the batched pins one at a time, as this target has no single port read wired up
+func readPort(pins []machine.Pin) uint32 {
+	var snap uint32
+	for i, p := range pins {
+		if p.Get() {
+			snap |= 1 << i
+		}
+	}
+	return snap
+}
@@ -0,0 +1,18 @@
+//go:build rp2040
+
+package bouncer
+
+import (
+	"device/rp"
+	"machine"
+)
+
+// readPort snapshots the batched pins from a single read of the SIO input register
+func readPort(pins []machine.Pin) uint32 {
+	in := rp.SIO.GPIO_IN.Get()
+	var snap uint32
+	for i, p := range pins {
+		snap |= (in >> p & 1) << i
+	}
+	return snap
+}
@@ -0,0 +1,47 @@
+package bouncer
+
+import "time"
+
+// Timing profiles for common kinds of switch, to use as a Config (or a starting point for one).
+// Debounce tick counts assume a systick of about 50 Hz (20ms), as in the example; each profile passes
+// the RequiredTickRate check at that rate
+var (
+	// ProfileTactile suits the 6mm & 12mm tactile buttons found on most boards
+	ProfileTactile = Config{
+		Short:         20 * time.Millisecond,
+		Long:          500 * time.Millisecond,
+		ExtraLong:     2 * time.Second,
+		DebounceTicks: 1,
+	}
+	// ProfileToggle suits maintained toggle & rocker switches
+	ProfileToggle = Config{
+		Mode:          ToggleMode,
+		DebounceTicks: 2,
+	}
+	// ProfileReed suits reed switches & magnetic contacts, which chatter for a long time as the magnet approaches
+	ProfileReed = Config{
+		Mode:          ReedMode,
+		DebounceTicks: 10,
+		MinState:      500 * time.Millisecond,
+	}
+	// ProfileRelayContact suits relay & contactor auxiliary contacts, which bounce for tens of milliseconds
+	ProfileRelayContact = Config{
+		Short:         60 * time.Millisecond,
+		Long:          1 * time.Second,
+		ExtraLong:     3 * time.Second,
+		DebounceTicks: 3,
+	}
+	// ProfileMicroswitch suits snap-action microswitches & limit switches, which bounce briefly
+	ProfileMicroswitch = Config{
+		Short:         20 * time.Millisecond,
+		Long:          400 * time.Millisecond,
+		ExtraLong:     1500 * time.Millisecond,
+		DebounceTicks: 1,
+	}
+	// ProfileHallEffect suits hall-effect sensors & optical endstops on a PulseCounter: their output is clean
+	// but fast, so the debounce is only long enough to reject a glitch, and either edge counts
+	ProfileHallEffect = PulseConfig{
+		Debounce: 50 * time.Microsecond,
+		AnyEdge:  true,
+	}
+)
@@ -0,0 +1,200 @@
+package bouncer
+
+import (
+	"errors"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"machine"
+)
+
+// PulseConfig configures a PulseCounter; zero values keep their defaults
+type PulseConfig struct {
+	Debounce time.Duration // edges this soon after a counted pulse are contact bounce; default 5ms
+	Interval time.Duration // counts are published once per Interval; default 1s
+	Polarity Polarity      // level the contact pulls the pin to when closed
+	Window   time.Duration // span of the sliding window Frequency is measured over; default Interval
+	// SkipPinConfigure leaves the pin mode alone, for a pin already set up by a board-support package
+	SkipPinConfigure bool
+	// AnyEdge counts every edge as a pulse, whichever way it goes, rather than only the contact closing.
+	// Suits clean sensors such as hall-effect & optical endstops, where it doubles the resolution
+	AnyEdge bool
+	// PulsesPerRev, if set, is how many pulses make one revolution, so RPM can be read
+	PulsesPerRev int
+}
+
+// pulseSamples is how many samples of the running total the sliding window holds
+const pulseSamples = 16
+
+type pulseSample struct {
+	at    Instant
+	total uint32
+}
+
+type pulseCounter struct {
+	pin        machine.Pin
+	debounce   time.Duration
+	interval   time.Duration
+	activeHigh bool
+	tickerCh   chan struct{}   // produced by sendTicks -> consumed by Run
+	done       chan struct{}   // closed by Shutdown, ending Run
+	stopped    uint32          // set once Shutdown has stopped the device
+	outChans   []chan<- uint32 // receive the count for each interval
+	total      uint32          // every pulse counted, set atomically by the interrupt handler
+	last       Instant         // time of the last counted pulse; only touched by the interrupt handler
+	listening  uint32          // pulse counters publish on a schedule, so they always listen for ticks
+	window     time.Duration
+	samples    [pulseSamples]pulseSample // ring of running totals, one every window/pulseSamples
+	sampled    int                       // samples taken so far
+	frequency  uint32                    // float32 bits of the latest frequency, set atomically so Frequency can read it
+	anyEdge    bool                      // every edge is a pulse, not only the contact closing
+	perRev     int                       // pulses per revolution, for RPM; zero if unknown
+}
+
+// PulseCounter counts debounced pulses from a contact such as a flow meter or anemometer reed,
+// publishing the number counted in each interval
+type PulseCounter interface {
+	Configure(PulseConfig) error
+	Run()
+	Count() uint32
+	Frequency() float32
+	RPM() float32
+}
+
+// NewPulseCounter returns a new PulseCounter (or error) for the given pin, publishing counts on the given channels
+func NewPulseCounter(p machine.Pin, outs ...chan<- uint32) (PulseCounter, error) {
+	if len(outs) < 1 {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	return &pulseCounter{
+		pin:      p,
+		debounce: 5 * time.Millisecond,
+		interval: time.Second,
+		tickerCh: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		outChans: outs,
+	}, nil
+}
+
+// Configure sets the pin mode & interrupt handler and subscribes the counter to ticks
+func (c *pulseCounter) Configure(cfg PulseConfig) error {
+	if cfg.Debounce < 0 || cfg.Interval < 0 || cfg.Window < 0 || cfg.PulsesPerRev < 0 {
+		return errors.New(ERROR_NEGATIVE_DURATION)
+	}
+	if cfg.Debounce > 0 {
+		c.debounce = cfg.Debounce
+	}
+	if cfg.Interval > 0 {
+		c.interval = cfg.Interval
+	}
+	c.window = c.interval
+	if cfg.Window > 0 {
+		c.window = cfg.Window
+	}
+	c.activeHigh = cfg.Polarity == ActiveHigh
+	c.anyEdge = cfg.AnyEdge
+	c.perRev = cfg.PulsesPerRev
+	mode := machine.PinInputPullup
+	if c.activeHigh {
+		mode = machine.PinInputPulldown
+	}
+	if !cfg.SkipPinConfigure {
+		c.pin.Configure(machine.PinConfig{Mode: mode})
+	}
+	if err := claimLines([]machine.Pin{c.pin}); err != nil {
+		return err
+	}
+	err := c.pin.SetInterrupt(machine.PinFalling|machine.PinRising, func(machine.Pin) {
+		if !c.anyEdge && c.pin.Get() != c.activeHigh { // contact opened; pulses are counted as they close
+			return
+		}
+		now := clockNow()
+		if d := now.Sub(c.last); !c.last.IsZero() && d >= 0 && d < c.debounce { // a compact Instant wraps negative
+			return
+		}
+		c.last = now
+		atomic.AddUint32(&c.total, 1)
+	})
+	if err != nil {
+		return errors.New(ERROR_INTERRUPT_UNAVAILABLE + ": " + err.Error())
+	}
+	addSysTickConsumer(c.tickerCh, &c.listening)
+	listen(&c.listening, true)
+	devices.add(c)
+	return nil
+}
+
+// Count returns the total number of pulses counted since Configure
+func (c *pulseCounter) Count() uint32 {
+	return atomic.LoadUint32(&c.total)
+}
+
+// Frequency returns the debounced pulse rate in Hz over the sliding window, e.g. for RPM from a tachometer contact.
+// It reads zero until Run has sampled for a while
+func (c *pulseCounter) Frequency() float32 {
+	return math.Float32frombits(atomic.LoadUint32(&c.frequency))
+}
+
+// RPM returns Frequency in revolutions per minute, given PulsesPerRev; zero if it wasn't set
+func (c *pulseCounter) RPM() float32 {
+	if c.perRev == 0 {
+		return 0
+	}
+	return c.Frequency() * 60 / float32(c.perRev)
+}
+
+// sample records the running total in the sliding window, then measures the rate across it
+func (c *pulseCounter) sample(now Instant) {
+	newest := pulseSample{at: now, total: c.Count()}
+	c.samples[c.sampled%pulseSamples] = newest
+	c.sampled++
+	oldest := c.samples[0]
+	if c.sampled > pulseSamples {
+		oldest = c.samples[c.sampled%pulseSamples]
+	}
+	span := newest.at.Sub(oldest.at)
+	if span <= 0 {
+		return
+	}
+	hz := float32(newest.total-oldest.total) / float32(span.Seconds())
+	atomic.StoreUint32(&c.frequency, math.Float32bits(hz))
+}
+
+// Run should be a goroutine; publishes the number of pulses counted in each interval, and samples the sliding
+// window for Frequency, checked on each tick
+func (c *pulseCounter) Run() {
+	start := clockNow()
+	from := c.Count()
+	c.sample(start)
+	lastSample := start
+	for {
+		select {
+		case <-c.tickerCh:
+		case <-c.done:
+			return
+		}
+		if now := clockNow(); now.Sub(lastSample) >= c.window/pulseSamples {
+			lastSample = now
+			c.sample(now)
+		}
+		if clockSince(start) < c.interval {
+			continue
+		}
+		start = start.Add(c.interval)
+		to := c.Count()
+		n := to - from // wraps correctly
+		from = to
+		for _, ch := range c.outChans {
+			ch <- n
+		}
+	}
+}
+
+// stop detaches the pin interrupt & ends Run, for Shutdown
+func (c *pulseCounter) stop() {
+	if halt(c.tickerCh, c.done, &c.listening, &c.stopped) {
+		c.pin.SetInterrupt(0, nil)
+		releaseLines([]machine.Pin{c.pin})
+	}
+}
@@ -0,0 +1,35 @@
+package bouncer
+
+import "time"
+
+// pressThresholds are a bouncer's press length thresholds. They're swapped as a whole, so a re-Configure
+// never leaves the recognizer classifying a press against a mix of old & new
+type pressThresholds struct {
+	short, long, extraLong time.Duration
+}
+
+// thresholds returns the bouncer's current press length thresholds
+func (b *bouncer) thresholds() pressThresholds {
+	return b.press.Load().(pressThresholds)
+}
+
+// detach undoes what a previous Configure attached, so configuring again replaces it rather than stacking:
+// the pin interrupts are released (as are the bouncer's hardware filters & port batch slot, to be reused),
+// and whatever carried edges to the recognizer is dropped
+func (b *bouncer) detach() {
+	for _, p := range b.pins {
+		p.SetInterrupt(0, nil)
+	}
+	b.detachFilters()
+	detachPort(b)
+	b.isrRing = nil
+	b.edgeFlag = nil
+	b.sensePins = nil
+	b.polled = false
+	b.edgeScheme = BothEdges
+}
+
+// configured reports whether Configure has already succeeded (or got as far as attaching edges) once
+func (b *bouncer) configured() bool {
+	return b.emit != nil
+}
@@ -0,0 +1,60 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Recorder captures the raw edges a bouncer's recognizer sees, for later inspection or Replay
+type Recorder struct {
+	edges []Edge
+	n     uint32 // edges recorded, stored atomically after each edge is written
+}
+
+// NewRecorder returns a Recorder with room for size edges; recording stops when it's full
+func NewRecorder(size int) *Recorder {
+	return &Recorder{edges: make([]Edge, size)}
+}
+
+// Edges returns the edges recorded so far; it's safe to call while recording continues
+func (r *Recorder) Edges() []Edge {
+	return r.edges[:atomic.LoadUint32(&r.n)]
+}
+
+// add records an edge if there's room
+func (r *Recorder) add(e Edge) {
+	n := atomic.LoadUint32(&r.n)
+	if int(n) == len(r.edges) {
+		return
+	}
+	r.edges[n] = e
+	atomic.StoreUint32(&r.n, n+1)
+}
+
+// Record has the recognizer capture every edge it sees into r. Call it before starting RecognizeAndPublish
+func (b *bouncer) Record(r *Recorder) {
+	b.recorder = r
+}
+
+// Replay feeds recorded edges to a configured bouncer exactly as its interrupt handler would, reproducing the
+// original spacing between them in real time, so a misbehaving switch captured in the field can be played back
+// through the recognizer on the bench. It blocks until the last edge has been fed; ticks come from the relay as usual
+func Replay(b Bouncer, edges []Edge) error {
+	bb, ok := b.(*bouncer)
+	if !ok {
+		return errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	if bb.emit == nil {
+		return errors.New(ERROR_NOT_CONFIGURED)
+	}
+	if len(edges) == 0 {
+		return nil
+	}
+	start := time.Now()
+	for _, e := range edges {
+		time.Sleep(time.Until(start.Add(e.Time.Sub(edges[0].Time))))
+		bb.emit(e.Up)
+	}
+	return nil
+}
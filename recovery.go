@@ -0,0 +1,137 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// RecoveryConfig configures a Recovery chord; zero durations keep their defaults
+type RecoveryConfig struct {
+	Hold    time.Duration // every button must be held together this long to arm; default 5s
+	Confirm time.Duration // once armed, letting go of every button within this fires; holding on longer disarms; default 2s
+	OnArmed func()        // optional; called when armed, e.g. to flash an LED telling the user to let go
+	OnFire  func()        // called when confirmed, e.g. machine.EnterBootloader or a reset
+}
+
+type recoveryState uint8
+
+const (
+	recoveryWaiting  recoveryState = iota // for every button to go down together
+	recoveryHolding                       // every button is down; arms after Hold
+	recoveryArmed                         // fires if every button is let go within Confirm
+	recoveryDisarmed                      // held past Confirm; waits for every button to be let go
+)
+
+type recovery struct {
+	buttons   []*bouncer
+	cfg       RecoveryConfig
+	state     recoveryState
+	since     Instant       // when the current state began
+	tickerCh  chan struct{} // produced by sendTicks -> consumed by Run
+	done      chan struct{} // closed by Shutdown, ending Run
+	stopped   uint32        // set once Shutdown has stopped the device
+	listening uint32        // recovery chords are polled, so they always listen for ticks
+}
+
+// Recovery watches for a chord of buttons held together, such as a bootloader or factory-reset combo, and fires a
+// callback only once the chord has been held, armed, and then let go: a device held down in a bag arms but never fires
+type Recovery interface {
+	Run()
+}
+
+// NewRecovery returns a new Recovery (or error) watching the given configured buttons, and subscribes it to ticks.
+// The buttons' recognizers (RecognizeAndPublish, or RecognizeShared) must be running, as they debounce them
+func NewRecovery(cfg RecoveryConfig, buttons ...Bouncer) (Recovery, error) {
+	if len(buttons) < 1 {
+		return nil, errors.New(ERROR_NO_BUTTONS)
+	}
+	if cfg.OnFire == nil {
+		return nil, errors.New(ERROR_NO_RECOVERY_ACTION)
+	}
+	if cfg.Hold < 0 || cfg.Confirm < 0 {
+		return nil, errors.New(ERROR_NEGATIVE_DURATION)
+	}
+	if cfg.Hold == 0 {
+		cfg.Hold = 5 * time.Second
+	}
+	if cfg.Confirm == 0 {
+		cfg.Confirm = 2 * time.Second
+	}
+	bs := make([]*bouncer, len(buttons))
+	for i, b := range buttons {
+		bb, ok := b.(*bouncer)
+		if !ok {
+			return nil, errors.New(ERROR_NOT_A_BOUNCER)
+		}
+		bs[i] = bb
+	}
+	r := &recovery{
+		buttons:  bs,
+		cfg:      cfg,
+		tickerCh: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	addSysTickConsumer(r.tickerCh, &r.listening)
+	listen(&r.listening, true)
+	devices.add(r)
+	return r, nil
+}
+
+// Run should be a goroutine; checks the buttons' debounced state on each tick and steps the chord through holding, armed & fired.
+// Letting go of any button before Hold starts over, and only letting go of every button confirms
+func (r *recovery) Run() {
+	for {
+		select {
+		case <-r.tickerCh:
+		case <-r.done:
+			return
+		}
+		all, some := r.held()
+		now := clockNow()
+		switch r.state {
+		case recoveryWaiting:
+			if all {
+				r.state, r.since = recoveryHolding, now
+			}
+		case recoveryHolding:
+			if !all {
+				r.state = recoveryWaiting
+			} else if now.Sub(r.since) >= r.cfg.Hold {
+				r.state, r.since = recoveryArmed, now
+				if r.cfg.OnArmed != nil {
+					r.cfg.OnArmed()
+				}
+			}
+		case recoveryArmed:
+			if !some {
+				r.state = recoveryWaiting
+				r.cfg.OnFire()
+			} else if now.Sub(r.since) >= r.cfg.Confirm {
+				r.state = recoveryDisarmed
+			}
+		case recoveryDisarmed:
+			if !some {
+				r.state = recoveryWaiting
+			}
+		}
+	}
+}
+
+// held reports whether all of the buttons, and whether any of them, are down once debounced, so a bounce can
+// neither arm nor fire the chord
+func (r *recovery) held() (all, some bool) {
+	all = true
+	for _, b := range r.buttons {
+		if b.debouncedDown() {
+			some = true
+		} else {
+			all = false
+		}
+	}
+	return all, some
+}
+
+// stop ends Run, for Shutdown
+func (r *recovery) stop() {
+	halt(r.tickerCh, r.done, &r.listening, &r.stopped)
+}
@@ -0,0 +1,151 @@
+package bouncer
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type sysTickSubscriber struct {
+	channel   chan struct{}
+	listening *uint32 // ticks are only sent while this is nonzero
+	lost      uint32  // ticks dropped because the subscriber hadn't taken the last one yet, updated atomically
+	every     int     // only every Nth tick is sent; 0 & 1 both mean every tick
+	skipped   int     // ticks skipped since the last one sent; only touched by the relay
+}
+
+// registryMu serializes changes to the package's registries. Each change replaces a registry's whole slice,
+// stored atomically, so readers (the relay, the dispatcher, recognizers, possibly in an interrupt or on another
+// core) take a consistent snapshot without locking. Bouncers may come & go while everything is running
+var registryMu sync.Mutex
+
+// tickRegistry holds everything subscribed to relayed ticks
+type tickRegistry struct {
+	subs atomic.Value // []*sysTickSubscriber
+}
+
+var tickSubscribers tickRegistry
+
+// snapshot returns the current subscribers; it mustn't be modified
+func (r *tickRegistry) snapshot() []*sysTickSubscriber {
+	subs, _ := r.subs.Load().([]*sysTickSubscriber)
+	return subs
+}
+
+// add subscribes s, replacing any subscription already receiving on the same channel in place
+func (r *tickRegistry) add(s *sysTickSubscriber) {
+	registryMu.Lock()
+	old := r.snapshot()
+	subs := make([]*sysTickSubscriber, len(old), len(old)+1)
+	copy(subs, old)
+	for i := range subs {
+		if subs[i].channel == s.channel {
+			subs[i] = s
+			r.subs.Store(subs)
+			registryMu.Unlock()
+			return
+		}
+	}
+	r.subs.Store(append(subs, s))
+	registryMu.Unlock()
+}
+
+// remove unsubscribes the subscriber receiving on ch, compacting the rest
+func (r *tickRegistry) remove(ch chan struct{}) {
+	registryMu.Lock()
+	old := r.snapshot()
+	subs := make([]*sysTickSubscriber, 0, len(old))
+	for _, s := range old {
+		if s.channel != ch {
+			subs = append(subs, s)
+		}
+	}
+	r.subs.Store(subs)
+	registryMu.Unlock()
+}
+
+// clear unsubscribes everything
+func (r *tickRegistry) clear() {
+	registryMu.Lock()
+	r.subs.Store([]*sysTickSubscriber(nil))
+	registryMu.Unlock()
+}
+
+// bouncerList is a registry of bouncers, such as every configured bouncer or those recognized by RecognizeShared
+type bouncerList struct {
+	v atomic.Value // []*bouncer
+}
+
+// snapshot returns the current bouncers; it mustn't be modified
+func (l *bouncerList) snapshot() []*bouncer {
+	bs, _ := l.v.Load().([]*bouncer)
+	return bs
+}
+
+// add registers b, unless it's already registered
+func (l *bouncerList) add(b *bouncer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	old := l.snapshot()
+	for _, x := range old {
+		if x == b {
+			return
+		}
+	}
+	bs := make([]*bouncer, len(old), len(old)+1)
+	copy(bs, old)
+	l.v.Store(append(bs, b))
+}
+
+// remove unregisters b, compacting the rest
+func (l *bouncerList) remove(b *bouncer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	old := l.snapshot()
+	bs := make([]*bouncer, 0, len(old))
+	for _, x := range old {
+		if x != b {
+			bs = append(bs, x)
+		}
+	}
+	l.v.Store(bs)
+}
+
+// clear unregisters every bouncer
+func (l *bouncerList) clear() {
+	registryMu.Lock()
+	l.v.Store([]*bouncer(nil))
+	registryMu.Unlock()
+}
+
+// deviceList is a registry of polled devices (DIP banks, selectors, joysticks...), for Shutdown
+type deviceList struct {
+	v atomic.Value // []device
+}
+
+// snapshot returns the current devices; it mustn't be modified
+func (l *deviceList) snapshot() []device {
+	ds, _ := l.v.Load().([]device)
+	return ds
+}
+
+// add registers d, unless it's already registered
+func (l *deviceList) add(d device) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	old := l.snapshot()
+	for _, x := range old {
+		if x == d {
+			return
+		}
+	}
+	ds := make([]device, len(old), len(old)+1)
+	copy(ds, old)
+	l.v.Store(append(ds, d))
+}
+
+// clear unregisters every device
+func (l *deviceList) clear() {
+	registryMu.Lock()
+	l.v.Store([]device(nil))
+	registryMu.Unlock()
+}
@@ -0,0 +1,35 @@
+package bouncer
+
+// remember keeps e as the bouncer's most recent event, for Topic.Replay
+func (b *bouncer) remember(e Event) {
+	b.lastMu.Lock()
+	b.last, b.hasLast = e, true
+	b.lastMu.Unlock()
+}
+
+// replay queues, for a new bus subscriber, the bouncer's most recent event and (if a debounced press is under way)
+// a Held event, each marked Replayed. They're delivered by the dispatcher, so they reach the subscriber in order
+// with anything published after it subscribed
+func (b *bouncer) replay(s busSubscriber) {
+	b.lastMu.Lock()
+	last, ok := b.last, b.hasLast
+	b.lastMu.Unlock()
+	if ok {
+		b.sendReplay(s, last)
+	}
+	if b.configured() && !b.maintained() && b.mode != VibrationMode && b.debouncedDown() {
+		b.sendReplay(s, Event{Pin: b.pins[0], Name: b.name, Length: Held})
+	}
+}
+
+// sendReplay queues e, marked Replayed, for the subscriber if its topic wants it
+func (b *bouncer) sendReplay(s busSubscriber, e Event) {
+	if !s.topic.matches(e) {
+		return
+	}
+	e.Replayed = true
+	if s.topic.Transform != nil {
+		e = s.topic.Transform(e)
+	}
+	enqueue(delivery{events: []chan<- Event{s.channel}, event: e})
+}
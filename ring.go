@@ -0,0 +1,43 @@
+package bouncer
+
+import "sync/atomic"
+
+// ringSize is the capacity of an edge ring; it must be a power of two
+const ringSize = 16
+
+// Edge is a single raw pin transition as captured by the interrupt handler
+type Edge struct {
+	Up   bool    // pin state after the transition; true is 'up' (released, with InputPullup)
+	Time Instant // time the interrupt fired
+}
+
+// ring is a lock-free single-producer/single-consumer queue of Edges.
+// The pin interrupt handler is the only producer and the recognizer is the only consumer,
+// so no channel operations happen in interrupt context
+type ring struct {
+	head uint32 // next slot to write; only stored by the producer
+	tail uint32 // next slot to read; only stored by the consumer
+	buf  [ringSize]Edge
+}
+
+// put appends an Edge, returning false if the ring is full and the Edge was dropped
+func (r *ring) put(e Edge) bool {
+	h := atomic.LoadUint32(&r.head)
+	if h-atomic.LoadUint32(&r.tail) == ringSize {
+		return false
+	}
+	r.buf[h&(ringSize-1)] = e
+	atomic.StoreUint32(&r.head, h+1)
+	return true
+}
+
+// get removes the oldest Edge, returning false if the ring is empty
+func (r *ring) get() (Edge, bool) {
+	t := atomic.LoadUint32(&r.tail)
+	if t == atomic.LoadUint32(&r.head) {
+		return Edge{}, false
+	}
+	e := r.buf[t&(ringSize-1)]
+	atomic.StoreUint32(&r.tail, t+1)
+	return e, true
+}
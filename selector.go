@@ -0,0 +1,114 @@
+package bouncer
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"machine"
+)
+
+const (
+	selectorStableTicks = 3  // ticks a new position must hold before it's published
+	selectorOpenTicks   = 12 // ticks every pin must stay open before the selector is considered to have no position
+	NoPosition          = -1 // the selector is between positions, or in an unwired one
+)
+
+type selector struct {
+	pins      []machine.Pin
+	tickerCh  chan struct{} // produced by sendTicks -> consumed by Run
+	done      chan struct{} // closed by Shutdown, ending Run
+	stopped   uint32        // set once Shutdown has stopped the device
+	outChans  []chan<- int  // receive the new position whenever it changes
+	position  int32         // debounced position, set atomically so Position can read it
+	candidate int           // position most recently sampled
+	count     int           // consecutive samples of candidate
+	listening uint32        // selectors are polled, so they always listen for ticks
+}
+
+// Selector tracks a break-before-make rotary selector switch wired to one pin per position
+type Selector interface {
+	Configure() error
+	Run()
+	Position() int
+}
+
+// NewSelector returns a new Selector (or error) for a switch whose position k closes pins[k] to ground;
+// each change of position is published on the given channels
+func NewSelector(pins []machine.Pin, outs ...chan<- int) (Selector, error) {
+	if len(pins) < 1 {
+		return nil, errors.New(ERROR_NO_PINS)
+	}
+	return &selector{
+		pins:     append([]machine.Pin(nil), pins...),
+		tickerCh: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		outChans: outs,
+	}, nil
+}
+
+// Configure sets the pins to InputPullup, takes the initial position, and subscribes the selector to ticks
+func (s *selector) Configure() error {
+	for _, p := range s.pins {
+		p.Configure(machine.PinConfig{Mode: machine.PinInputPullup})
+	}
+	s.candidate = s.sample()
+	atomic.StoreInt32(&s.position, int32(s.candidate))
+	addSysTickConsumer(s.tickerCh, &s.listening)
+	listen(&s.listening, true)
+	devices.add(s)
+	return nil
+}
+
+// Position returns the debounced position, or NoPosition
+func (s *selector) Position() int {
+	return int(atomic.LoadInt32(&s.position))
+}
+
+// Run should be a goroutine; samples the pins on each tick and publishes a position once it has settled.
+// The brief all-open gap while the switch travels between detents is ignored unless it persists
+func (s *selector) Run() {
+	for {
+		select {
+		case <-s.tickerCh:
+		case <-s.done:
+			return
+		}
+		k := s.sample()
+		if k != s.candidate {
+			s.candidate = k
+			s.count = 0
+		}
+		s.count += 1
+		need := selectorStableTicks
+		if k == NoPosition {
+			need = selectorOpenTicks
+		}
+		if s.count != need || k == s.Position() {
+			continue
+		}
+		atomic.StoreInt32(&s.position, int32(k))
+		for _, ch := range s.outChans {
+			ch <- k
+		}
+	}
+}
+
+// sample returns the index of the single closed pin, or NoPosition if none or several are closed
+func (s *selector) sample() int {
+	k := NoPosition
+	for i, p := range s.pins {
+		if p.Get() {
+			continue
+		}
+		if k != NoPosition {
+			return NoPosition
+		}
+		k = i
+	}
+	return k
+}
+
+// stop ends Run, for Shutdown
+func (s *selector) stop() {
+	halt(s.tickerCh, s.done, &s.listening, &s.stopped)
+}
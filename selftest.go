@@ -0,0 +1,61 @@
+package bouncer
+
+import (
+	"time"
+
+	"machine"
+)
+
+// selfTestTimeout is how long SelfTest waits for each thing it checks
+const selfTestTimeout = 100 * time.Millisecond
+
+// SelfTestResult reports what SelfTest found; the loopback checks are false if no loopback pin was given
+type SelfTestResult struct {
+	Configured bool // Configure has succeeded
+	Interrupt  bool // edges arrive by interrupt rather than polling (see InterruptBacked)
+	Ticks      bool // ticks are reaching the bouncer
+	Loopback   bool // a loopback pin was driven
+	Level      bool // the bouncer's pins followed the loopback pin
+	Edges      bool // the press & release each arrived as an edge (from the interrupt handler, if Interrupt)
+}
+
+// OK reports whether every check that was run passed
+func (r SelfTestResult) OK() bool {
+	return r.Configured && r.Ticks && (!r.Loopback || (r.Level && r.Edges))
+}
+
+// SelfTest checks a configured bouncer's wiring for production-line testing: that ticks are flowing and, given
+// a loopback pin jumpered to the bouncer's pin (machine.NoPin for none), that driving it presses & releases the
+// button as far as the pin level and the interrupt handler are concerned. The loopback pin is left driven at
+// the released level. SelfTest reads the bouncer's ticks & edges itself, so call it before RecognizeAndPublish
+func (b *bouncer) SelfTest(loopback machine.Pin) SelfTestResult {
+	r := SelfTestResult{Configured: b.emit != nil}
+	if !r.Configured {
+		return r
+	}
+	r.Interrupt = b.InterruptBacked()
+	b.setListening(true)
+	defer func() { b.setListening(b.needsTicks()) }()
+	timer := time.NewTimer(selfTestTimeout)
+	select {
+	case <-b.tickerCh:
+		r.Ticks = true
+	case <-timer.C:
+	}
+	timer.Stop()
+	if loopback == machine.NoPin {
+		return r
+	}
+	r.Loopback = true
+	loopback.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	level := b.get()
+	loopback.Set(b.activeHigh) // press
+	pressed, pressEdge := b.nextEdge(&level, selfTestTimeout, true)
+	down := !b.get()
+	loopback.Set(!b.activeHigh) // release
+	released, releaseEdge := b.nextEdge(&level, selfTestTimeout, true)
+	up := b.get()
+	r.Level = down && up
+	r.Edges = pressEdge && !pressed.Up && releaseEdge && released.Up
+	return r
+}
@@ -0,0 +1,47 @@
+//go:build nrf52 || nrf52833 || nrf52840
+
+package bouncer
+
+import (
+	"device/nrf"
+	"errors"
+
+	"machine"
+)
+
+// attachSense watches a pin through its SENSE mechanism rather than a GPIOTE channel. The sensed level
+// is latched in P0.LATCH without needing an interrupt, and picked up by pollSense on each tick
+func (b *bouncer) attachSense(p machine.Pin) error {
+	if p >= 32 {
+		return errors.New(ERROR_SENSE_UNSUPPORTED)
+	}
+	nrf.P0.DETECTMODE.Set(nrf.GPIO_DETECTMODE_DETECTMODE_LDETECT)
+	armSense(p)
+	nrf.P0.LATCH.Set(1 << p)
+	b.sensePins = append(b.sensePins, p)
+	return nil
+}
+
+// armSense sets a pin to sense the opposite of its current level
+func armSense(p machine.Pin) {
+	sense := uint32(nrf.GPIO_PIN_CNF_SENSE_Low)
+	if !p.Get() {
+		sense = nrf.GPIO_PIN_CNF_SENSE_High
+	}
+	nrf.P0.PIN_CNF[p].ReplaceBits(sense, nrf.GPIO_PIN_CNF_SENSE_Msk>>nrf.GPIO_PIN_CNF_SENSE_Pos, nrf.GPIO_PIN_CNF_SENSE_Pos)
+}
+
+// pollSense reports whether any of the pins has latched a change since the last poll, re-arming those which have
+func pollSense(pins []machine.Pin) bool {
+	changed := false
+	latch := nrf.P0.LATCH.Get()
+	for _, p := range pins {
+		if latch&(1<<p) == 0 {
+			continue
+		}
+		armSense(p)
+		nrf.P0.LATCH.Set(1 << p) // write 1 to clear
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,19 @@
+//go:build !(nrf52 || nrf52833 || nrf52840)
+
+package bouncer
+
+import (
+	"errors"
+
+	"machine"
+)
+
+// attachSense is unsupported on this target
+func (b *bouncer) attachSense(p machine.Pin) error {
+	return errors.New(ERROR_SENSE_UNSUPPORTED)
+}
+
+// pollSense never has anything to report on this target
+func pollSense(pins []machine.Pin) bool {
+	return false
+}
@@ -0,0 +1,26 @@
+package bouncer
+
+var (
+	sharedBouncers bouncerList              // bouncers configured with Shared
+	sharedWake     = make(chan struct{}, 1) // nudged whenever a shared bouncer may have an edge or tick waiting
+)
+
+// nudgeShared wakes RecognizeShared without blocking; a nudge already pending covers this one
+func nudgeShared() {
+	select {
+	case sharedWake <- struct{}{}:
+	default:
+	}
+}
+
+// RecognizeShared should be a goroutine, started once after configuring every bouncer with Shared;
+// it recognizes & publishes presses for all of them, in place of a RecognizeAndPublish goroutine per bouncer.
+// Each wake-up polls every shared bouncer, so one busy button briefly delays the others' recognition
+func RecognizeShared() {
+	startDispatcher()
+	for range sharedWake {
+		for _, b := range sharedBouncers.snapshot() {
+			b.poll()
+		}
+	}
+}
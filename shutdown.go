@@ -0,0 +1,78 @@
+package bouncer
+
+import "sync/atomic"
+
+// registered holds every configured bouncer, for Shutdown
+var registered bouncerList
+
+// device is anything other than a bouncer which takes ticks or interrupts, and so must be stopped by Shutdown
+type device interface {
+	stop()
+}
+
+// devices holds every configured device, for Shutdown
+var devices deviceList
+
+// halt unsubscribes a device from ticks and closes done, ending its goroutine; false if it was already halted
+func halt(tickerCh, done chan struct{}, listening, stopped *uint32) bool {
+	if !atomic.CompareAndSwapUint32(stopped, 0, 1) {
+		return false
+	}
+	listen(listening, false)
+	tickSubscribers.remove(tickerCh)
+	close(done)
+	return true
+}
+
+// Close detaches the bouncer's pin interrupts (and hardware filters & port slot), stops its RecognizeAndPublish,
+// and unsubscribes it from ticks. A closed bouncer can't be used again
+func (b *bouncer) Close() {
+	if !atomic.CompareAndSwapUint32(&b.closed, 0, 1) {
+		return
+	}
+	b.recMu.Lock() // let the recognizer finish the edge or tick in hand
+	b.detach()
+	b.recMu.Unlock()
+	releaseLines(b.pins)
+	b.setListening(false)
+	tickSubscribers.remove(b.tickerCh)
+	registered.remove(b)
+	sharedBouncers.remove(b)
+	close(b.done)
+}
+
+// Shutdown quiesces the whole input subsystem, e.g. before entering a bootloader or DFU mode: every configured
+// bouncer is closed, every device (DIP banks, selectors, joysticks, analog inputs, pulse counters, two-hand controls
+// & recovery chords) is stopped, its Run or RecognizeAndPublish returning and a pulse counter's interrupt detached,
+// the tick relay stops, and all tick subscriptions are dropped. Stopped devices can't be used again
+func Shutdown() {
+	for _, b := range registered.snapshot() {
+		b.Close()
+	}
+	registered.clear()
+	for _, d := range devices.snapshot() {
+		d.stop()
+	}
+	devices.clear()
+	sharedBouncers.clear()
+	StopRelay()
+	tickSubscribers.clear()
+	atomic.StoreInt32(&listeningBouncers, 0)
+}
+
+// StopRelay stops Debounce, along with the timer StartTimerTicking runs, e.g. to move to another tick source.
+// Ticks already waiting for the relay are drained rather than relayed. Bouncers keep their configuration,
+// and pick up where they left off once another relay starts
+func StopRelay() {
+	relayMu.Lock()
+	close(relayStop)
+	relayStop = make(chan struct{})
+	relayMu.Unlock()
+}
+
+// currentRelayStop returns the channel the next StopRelay will close
+func currentRelayStop() chan struct{} {
+	relayMu.Lock()
+	defer relayMu.Unlock()
+	return relayStop
+}
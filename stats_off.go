@@ -0,0 +1,53 @@
+//go:build bouncer_nostats
+
+package bouncer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// tickMeter only keeps the mean interval between ticks, which the overrun check needs;
+// the rest of TickStats is compiled out by the bouncer_nostats tag
+type tickMeter struct {
+	last Instant       // the previous tick; zero if the recognizer has stopped listening since
+	gap  time.Duration // unused in this build
+	mean uint32        // microseconds, set atomically
+}
+
+// tick folds the interval since the previous tick into the mean
+func (m *tickMeter) tick(now Instant) {
+	last := m.last
+	m.last = now
+	if last.IsZero() {
+		return
+	}
+	us := uint32(now.Sub(last) / time.Microsecond)
+	mean := atomic.LoadUint32(&m.mean)
+	if mean == 0 {
+		atomic.StoreUint32(&m.mean, us)
+		return
+	}
+	atomic.StoreUint32(&m.mean, uint32(int32(mean)+(int32(us)-int32(mean))/8))
+}
+
+// pause forgets the previous tick
+func (m *tickMeter) pause() {
+	m.last = never
+}
+
+// TickStats only has MeanInterval in this build
+func (b *bouncer) TickStats() TickStats {
+	return TickStats{MeanInterval: time.Duration(atomic.LoadUint32(&b.tickMeter.mean)) * time.Microsecond}
+}
+
+// Counts is always zero in this build
+func (b *bouncer) Counts() PressCounts {
+	return PressCounts{}
+}
+
+// ResetCounts has nothing to reset
+func (b *bouncer) ResetCounts() {}
+
+// count doesn't count in this build
+func (b *bouncer) count(p PressLength) {}
@@ -0,0 +1,76 @@
+package bouncer
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// swipe correlates the debounced presses of two bouncers, each recognized in its own goroutine
+type swipe struct {
+	mu           sync.Mutex
+	a, b         *bouncer
+	downA, downB Instant // debounced down times of each bouncer's latest press
+	window       time.Duration
+	outs         []chan<- PressLength
+}
+
+// BindSwipe publishes SwipeNext on outs when a is pressed then b within window, or SwipePrevious for b then a,
+// for next/previous on two-button devices. The presses are matched on their debounced down times while both
+// are held, and neither is then published itself. A zero window defaults to 150ms.
+// Bind before starting either bouncer's RecognizeAndPublish
+func BindSwipe(a, b Bouncer, window time.Duration, outs ...chan<- PressLength) error {
+	ab, ok := a.(*bouncer)
+	if !ok {
+		return errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	bb, ok := b.(*bouncer)
+	if !ok {
+		return errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	if len(outs) < 1 {
+		return errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	if window < 0 {
+		return errors.New(ERROR_NEGATIVE_DURATION)
+	}
+	if window == 0 {
+		window = 150 * time.Millisecond
+	}
+	s := &swipe{a: ab, b: bb, window: window, outs: outs}
+	ab.swipe, bb.swipe = s, s
+	return nil
+}
+
+// pressed is called by either bouncer once its press is debounced, and publishes a swipe if the other
+// bouncer is held following a press that came down within the window of this one. The swipe is published by
+// the bouncer pressed second, as its own events are, but on the swipe's channels
+func (s *swipe) pressed(by *bouncer, at Instant) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	other, otherAt := s.b, s.downB
+	if by == s.a {
+		s.downA = at
+	} else {
+		s.downB = at
+		other, otherAt = s.a, s.downA
+	}
+	if atomic.LoadUint32(&other.held) == 0 || atomic.LoadUint32(&other.swiped) == 1 {
+		return
+	}
+	gap := at.Sub(otherAt)
+	if gap < 0 {
+		gap = -gap
+	}
+	if gap > s.window {
+		return
+	}
+	dir := SwipeNext
+	if s.downB.Before(s.downA) {
+		dir = SwipePrevious
+	}
+	atomic.StoreUint32(&by.swiped, 1)
+	atomic.StoreUint32(&other.swiped, 1)
+	by.publishTo(dir, s.outs)
+}
@@ -0,0 +1,37 @@
+//go:build cortexm && bouncer_systick
+
+package bouncer
+
+import (
+	"device/arm"
+	"errors"
+
+	"machine"
+)
+
+// sysTicks is fed by the package's own SysTick_Handler and consumed by Debounce
+var sysTicks = make(chan struct{}, 1)
+
+//go:export SysTick_Handler
+func handleSysTick() {
+	select {
+	case sysTicks <- struct{}{}:
+	default:
+	}
+}
+
+// StartTicking sets up the system timer to fire hz times per second and relays its ticks to all bouncers,
+// replacing the SysTick_Handler & Debounce plumbing otherwise needed in main. It's only built with the
+// bouncer_systick tag, since the package then owns SysTick_Handler; applications which share the systick
+// should leave the tag off and feed Debounce themselves
+func StartTicking(hz uint32) error {
+	if hz == 0 {
+		return errors.New(ERROR_INVALID_TICK_RATE)
+	}
+	if err := arm.SetupSystemTimer(machine.CPUFrequency() / hz); err != nil {
+		return err
+	}
+	SetTickRate(hz)
+	go Debounce(sysTicks)
+	return nil
+}
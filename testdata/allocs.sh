@@ -0,0 +1,8 @@
+#!/bin/sh
+# Runs the allocation tests & benchmark on the host, with testdata/machine standing in for TinyGo's machine package
+set -e
+cd "$(dirname "$0")/.."
+overlay=$(mktemp)
+trap 'rm -f "$overlay"' EXIT
+printf '{"Replace":{"%s/src/machine/machine.go":"%s/testdata/machine/machine.go"}}\n' "$(go env GOROOT)" "$PWD" >"$overlay"
+go test -overlay="$overlay" -tags bouncer_allocs -run Allocs -bench Press -benchmem "$@" .
@@ -0,0 +1,48 @@
+// Package machine stands in for TinyGo's machine package on a desktop host, for the allocation tests.
+// Pins remember the level last Set, which Get reads back; interrupts are never raised, so the tests
+// call the bouncer's handler themselves
+package machine
+
+import "errors"
+
+type Pin uint8
+type PinMode uint8
+type PinChange uint8
+
+const (
+	PinInput PinMode = iota
+	PinInputPullup
+	PinInputPulldown
+	PinOutput
+)
+
+const (
+	PinRising PinChange = 1 << iota
+	PinFalling
+	PinToggle = PinRising | PinFalling
+)
+
+const NoPin = Pin(0xff)
+
+type PinConfig struct{ Mode PinMode }
+
+var levels [256]bool
+
+var ErrInvalidInputPin = errors.New("invalid input pin")
+
+func (p Pin) Configure(c PinConfig)                        {}
+func (p Pin) Get() bool                                    { return levels[p] }
+func (p Pin) Set(b bool)                                   { levels[p] = b }
+func (p Pin) High()                                        {}
+func (p Pin) Low()                                         {}
+func (p Pin) SetInterrupt(c PinChange, cb func(Pin)) error { return nil }
+func CPUFrequency() uint32                                 { return 64000000 }
+
+const (
+	D0 Pin = iota
+	D1
+	D2
+	D3
+	D4
+	D5
+)
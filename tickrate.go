@@ -0,0 +1,45 @@
+package bouncer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var declaredTickRate uint32 // Hz, from StartTicking, StartTimerTicking or SetTickRate; zero if never declared
+
+// SetTickRate declares the rate at which ticks are relayed, for applications feeding Debounce or Tick from their
+// own SysTick_Handler; StartTicking & StartTimerTicking declare it themselves. Configure then refuses a bouncer
+// whose debounce & shortest press the rate is too slow to see
+func SetTickRate(hz uint32) {
+	atomic.StoreUint32(&declaredTickRate, hz)
+}
+
+// RequiredTickRate returns the slowest tick rate, in Hz, which recognizes every press of cfg: a press lasting
+// Short must span DebounceTicks ticks to count at all, so a slower tick turns short presses into nothing.
+// It's zero where press lengths are counted in ticks, or the mode doesn't time presses
+func RequiredTickRate(cfg Config) uint32 {
+	short := 22 * time.Millisecond
+	if cfg.Short > 0 {
+		short = cfg.Short
+	}
+	debounceTicks := 1
+	if cfg.DebounceTicks > 0 {
+		debounceTicks = cfg.DebounceTicks
+	}
+	return requiredTickRate(cfg, short, debounceTicks)
+}
+
+// requiredTickRate does the work of RequiredTickRate with the bouncer's merged short & debounceTicks
+func requiredTickRate(cfg Config, short time.Duration, debounceTicks int) uint32 {
+	if cfg.ShortTicks > 0 || cfg.Mode == ToggleMode || cfg.Mode == ReedMode || cfg.Mode == VibrationMode || cfg.Mode == EStopMode {
+		return 0
+	}
+	need := time.Duration(debounceTicks) * time.Second
+	return uint32((need + short - 1) / short)
+}
+
+// tickRate returns the declared tick rate; zero if none has been. A measured rate isn't used, as a little jitter
+// would make a rate which is just fast enough look too slow
+func tickRate() uint32 {
+	return atomic.LoadUint32(&declaredTickRate)
+}
@@ -0,0 +1,38 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// timerTicks is fed by runTimer and consumed by Debounce
+var timerTicks = make(chan struct{}, 1)
+
+// StartTimerTicking relays ticks hz times per second to all bouncers using the runtime's sleep timer, which
+// TinyGo drives from the target's RTC or low-frequency timer. Use it on chips without an ARM SysTick
+// (ESP32-C3, AVR, RISC-V) in place of a SysTick_Handler and Debounce
+func StartTimerTicking(hz uint32) error {
+	if hz == 0 {
+		return errors.New(ERROR_INVALID_TICK_RATE)
+	}
+	SetTickRate(hz)
+	go runTimer(time.Second/time.Duration(hz), currentRelayStop())
+	go Debounce(timerTicks)
+	return nil
+}
+
+// runTimer feeds timerTicks every period until stop is closed, dropping ticks if the relay falls behind just as a
+// SysTick_Handler would
+func runTimer(period time.Duration, stop chan struct{}) {
+	next := time.Now()
+	for {
+		next = next.Add(period)
+		time.Sleep(time.Until(next)) // sleep to a deadline so time spent relaying doesn't accumulate as drift
+		select {
+		case <-stop: // StopRelay
+			return
+		case timerTicks <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package bouncer
+
+import "sync/atomic"
+
+// handleToggleEdge (re)starts the settling period of a maintained switch; every edge,
+// whichever its direction, postpones the decision until the pin has been quiet for DebounceTicks
+func (b *bouncer) handleToggleEdge(e Edge) {
+	b.ticks = 1
+	b.btnDown = e.Time // time of the latest edge, from which MinState is measured
+}
+
+// handleToggleTick publishes On or Off (Closed or Open in ReedMode) once a maintained switch has settled in a new position
+func (b *bouncer) handleToggleTick() {
+	if b.ticks == 0 { // the switch is settled
+		return
+	}
+	b.ticks += 1
+	if b.ticks < b.debounceTicks+2 { // the first tick may arrive just after the edge; wait out whole tick intervals
+		return
+	}
+	if clockSince(b.btnDown) < b.minState { // quiet, but not yet for long enough
+		return
+	}
+	b.ticks = 0
+	up := b.get()
+	if up == b.State() { // bounced back to where it was
+		return
+	}
+	b.setSwitchUp(up)
+	switch {
+	case b.mode == ReedMode && up:
+		b.publish(Open)
+	case b.mode == ReedMode:
+		b.publish(Closed)
+	case up:
+		b.publish(Off)
+	default:
+		b.publish(On)
+	}
+}
+
+// maintained reports whether the bouncer is reading a maintained switch rather than a momentary button
+func (b *bouncer) maintained() bool {
+	return b.mode == ToggleMode || b.mode == ReedMode
+}
+
+// setSwitchUp stores the debounced position of a maintained switch
+func (b *bouncer) setSwitchUp(up bool) {
+	if up {
+		atomic.StoreUint32(&b.switchUp, 1)
+	} else {
+		atomic.StoreUint32(&b.switchUp, 0)
+	}
+}
+
+// toggleLatch flips the latched state of a LatchMode bouncer, returning the event announcing the new state
+func (b *bouncer) toggleLatch() PressLength {
+	if atomic.LoadUint32(&b.latched) == 1 {
+		atomic.StoreUint32(&b.latched, 0)
+		return StateOff
+	}
+	atomic.StoreUint32(&b.latched, 1)
+	return StateOn
+}
@@ -0,0 +1,37 @@
+package bouncer
+
+import "sync"
+
+// trace is a ring of the most recent raw edges, overwriting the oldest
+type trace struct {
+	mu    sync.Mutex
+	edges []Edge
+	next  int  // slot the next edge goes in
+	full  bool // every slot holds an edge
+}
+
+// add records an edge, overwriting the oldest once the ring is full
+func (t *trace) add(e Edge) {
+	t.mu.Lock()
+	t.edges[t.next] = e
+	t.next++
+	if t.next == len(t.edges) {
+		t.next, t.full = 0, true
+	}
+	t.mu.Unlock()
+}
+
+// DumpTrace returns a copy of the last Config.TraceEdges raw edges the recognizer saw, oldest first, to show
+// the actual waveform behind a reported double trigger. It returns nil if tracing isn't configured
+func (b *bouncer) DumpTrace() []Edge {
+	t := b.trace
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.full {
+		return append([]Edge(nil), t.edges[:t.next]...)
+	}
+	return append(append(make([]Edge, 0, len(t.edges)), t.edges[t.next:]...), t.edges[:t.next]...)
+}
@@ -0,0 +1,27 @@
+package bouncer
+
+// transforms holds the functions attached to individual PressLength subscribers by Transform
+var transforms map[chan<- PressLength]func(Event) Event
+
+// Transform attaches f to ch, so that every event delivered to ch (whichever bouncer publishes it) is first passed
+// through f; the channel receives the Length of the Event f returns. Other subscribers are unaffected.
+// Use it to keep per-consumer adaptation, such as inverted semantics or remapped tiers, out of application glue.
+// Attach during setup, before bouncers begin publishing; a nil f detaches
+func Transform(ch chan<- PressLength, f func(Event) Event) {
+	if f == nil {
+		delete(transforms, ch)
+		return
+	}
+	if transforms == nil {
+		transforms = make(map[chan<- PressLength]func(Event) Event)
+	}
+	transforms[ch] = f
+}
+
+// transformed returns e as the subscriber ch should receive it
+func transformed(ch chan<- PressLength, e Event) Event {
+	if f, ok := transforms[ch]; ok {
+		return f(e)
+	}
+	return e
+}
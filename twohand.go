@@ -0,0 +1,127 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+type twoHandState uint8
+
+const (
+	twoHandReady     twoHandState = iota // both buttons up
+	twoHandFirst                         // one button down; the other must follow within the window
+	twoHandActive                        // both down in time; Activate has been published
+	twoHandLockedOut                     // too slow, or aborted; waits for both buttons to be let go
+)
+
+type twoHand struct {
+	left, right *bouncer
+	window      time.Duration
+	state       twoHandState
+	first       Instant              // when the first button went down
+	tickerCh    chan struct{}        // produced by sendTicks -> consumed by Run
+	done        chan struct{}        // closed by Shutdown, ending Run
+	stopped     uint32               // set once Shutdown has stopped the device
+	outChans    []chan<- PressLength // receive Activate & Deactivate
+	listening   uint32               // two-hand controls are polled, so they always listen for ticks
+}
+
+// TwoHand is the two-hand control interlock guarding a machine start: both buttons must be pressed within a
+// short window of each other and held together to Activate, and letting go of either Deactivates
+type TwoHand interface {
+	Run()
+}
+
+// NewTwoHand returns a new TwoHand (or error) watching two configured momentary buttons, publishing on outs; a zero
+// window defaults to the customary 500ms. It subscribes itself to ticks. The buttons' recognizers
+// (RecognizeAndPublish, or RecognizeShared) must be running, as they debounce them
+func NewTwoHand(left, right Bouncer, window time.Duration, outs ...chan<- PressLength) (TwoHand, error) {
+	if len(outs) < 1 {
+		return nil, errors.New(ERROR_NO_OUTPUT_CHANNELS)
+	}
+	if window < 0 {
+		return nil, errors.New(ERROR_NEGATIVE_DURATION)
+	}
+	if window == 0 {
+		window = 500 * time.Millisecond
+	}
+	l, ok := left.(*bouncer)
+	if !ok {
+		return nil, errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	r, ok := right.(*bouncer)
+	if !ok {
+		return nil, errors.New(ERROR_NOT_A_BOUNCER)
+	}
+	t := &twoHand{
+		left:     l,
+		right:    r,
+		window:   window,
+		tickerCh: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		outChans: outs,
+	}
+	addSysTickConsumer(t.tickerCh, &t.listening)
+	listen(&t.listening, true)
+	devices.add(t)
+	return t, nil
+}
+
+// Run should be a goroutine; checks both buttons' debounced state on each tick, publishing Activate once both are
+// down with the second pressed within the window of the first, and Deactivate as soon as either is let go. Pressing
+// too slowly, or letting go, locks the control out until both buttons have been released, so it can't be re-armed
+// one-handed
+func (t *twoHand) Run() {
+	for {
+		select {
+		case <-t.tickerCh:
+		case <-t.done:
+			return
+		}
+		l, r := t.left.debouncedDown(), t.right.debouncedDown() // a bounce can't lock the control out
+		switch t.state {
+		case twoHandReady:
+			if l && r { // both at once
+				t.activate()
+			} else if l || r {
+				t.state, t.first = twoHandFirst, clockNow()
+			}
+		case twoHandFirst:
+			switch {
+			case l && r && clockSince(t.first) <= t.window:
+				t.activate()
+			case !l && !r:
+				t.state = twoHandReady
+			case clockSince(t.first) > t.window:
+				t.state = twoHandLockedOut
+			}
+		case twoHandActive:
+			if !l || !r {
+				t.state = twoHandLockedOut
+				t.publish(Deactivate)
+			}
+		case twoHandLockedOut:
+			if !l && !r {
+				t.state = twoHandReady
+			}
+		}
+	}
+}
+
+// activate moves to the active state, publishing Activate
+func (t *twoHand) activate() {
+	t.state = twoHandActive
+	t.publish(Activate)
+}
+
+// publish sends p to every output channel
+func (t *twoHand) publish(p PressLength) {
+	for _, ch := range t.outChans {
+		ch <- p
+	}
+}
+
+// stop ends Run, for Shutdown
+func (t *twoHand) stop() {
+	halt(t.tickerCh, t.done, &t.listening, &t.stopped)
+}
@@ -0,0 +1,96 @@
+package bouncer
+
+import "sync/atomic"
+
+// Update performs one pass of recognition without blocking: it handles whatever edges & tick are waiting,
+// then delivers any recognized events. Call it from the main loop of firmware which can't spare a goroutine
+// (or a stack) for RecognizeAndPublish, such as builds with -scheduler=none, and feed it ticks with Tick
+// rather than Debounce. The bouncer must be configured with SuperLoop (and so Ring or EdgeFlag), since the
+// default edge channel can block inside the interrupt. Subscribers must be buffered channels, since nothing
+// else is running to receive
+func (b *bouncer) Update() {
+	b.poll()
+	deliverPending()
+}
+
+// poll handles whatever edges & tick are waiting for the bouncer, without blocking
+func (b *bouncer) poll() {
+	for {
+		select {
+		case <-b.tickerCh:
+			b.recMu.Lock()
+			start := clockNow()
+			b.onTick()
+			b.checkOverrun(start, true)
+			b.recMu.Unlock()
+		case e := <-b.isrChan:
+			b.recMu.Lock()
+			start := clockNow()
+			b.handleEdge(e)
+			b.checkOverrun(start, false)
+			b.recMu.Unlock()
+		default:
+			b.recMu.Lock()
+			b.setListening(b.needsTicks())
+			b.recMu.Unlock()
+			return
+		}
+	}
+}
+
+// Tick relays one tick to every listening bouncer without blocking, dropping it for any bouncer which hasn't
+// taken the last one yet. Unlike Debounce it needs no goroutine, so super-loop firmware may call it directly
+// from its SysTick_Handler
+func Tick() {
+	sendTicks()
+}
+
+// deliverPending delivers the queued events from the calling goroutine. Subscribers which aren't ready
+// to receive miss the event, which is counted as dropped
+func deliverPending() {
+	for {
+		select {
+		case d := <-dispatchQueue:
+			for _, ch := range d.outs {
+				offer(ch, transformed(ch, d.event).Length)
+			}
+			for _, ch := range d.events {
+				select {
+				case ch <- d.event:
+				default:
+					atomic.AddUint32(&droppedEvents, 1)
+				}
+			}
+			if d.handler != nil {
+				d.handler()
+			}
+			if !d.bus {
+				continue
+			}
+			for _, sub := range busSnapshot() {
+				if sub.topic.matches(d.event) {
+					e := d.event
+					if t := sub.topic.Transform; t != nil {
+						e = t(e)
+					}
+					select {
+					case sub.channel <- e:
+					default:
+						atomic.AddUint32(&droppedEvents, 1)
+					}
+				}
+			}
+		default:
+			return
+		}
+	}
+}
+
+// offer sends p on ch if ch can take it, counting it as dropped otherwise
+func offer(ch chan<- PressLength, p PressLength) {
+	select {
+	case ch <- p:
+	default:
+		atomic.AddUint32(&droppedEvents, 1)
+	}
+}
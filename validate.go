@@ -0,0 +1,65 @@
+package bouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// mergeDurations returns the config's press thresholds, keeping the given defaults wherever the config's are zero
+func mergeDurations(cfg Config, short, long, extraLong time.Duration) (time.Duration, time.Duration, time.Duration) {
+	if cfg.Short != 0 {
+		short = cfg.Short
+	}
+	if cfg.Long != 0 {
+		long = cfg.Long
+	}
+	if cfg.ExtraLong != 0 {
+		extraLong = cfg.ExtraLong
+	}
+	return short, long, extraLong
+}
+
+// validate checks a config, given the press thresholds it will result in
+func validate(cfg Config, short, long, extraLong time.Duration) error {
+	for _, d := range []time.Duration{short, cfg.ClickWindow, cfg.StuckAfter, cfg.HardwareFilter, cfg.MinState, cfg.VibrationWindow, cfg.Cooldown, cfg.Max, cfg.GlitchFilter, cfg.DegradedBounce, cfg.TickGap, cfg.Heartbeat, cfg.IdleAfter} {
+		if d < 0 {
+			return errors.New(ERROR_NEGATIVE_DURATION)
+		}
+	}
+	for _, n := range []int{cfg.DebounceTicks, cfg.ShortTicks, cfg.LongTicks, cfg.ExtraLongTicks, cfg.VibrationEdges, cfg.TraceEdges, cfg.TickEvery} {
+		if n < 0 {
+			return errors.New(ERROR_NEGATIVE_DURATION)
+		}
+	}
+	// disabled tiers are left out of the order, but those which are enabled must be in order
+	last := short
+	for _, d := range []time.Duration{long, extraLong} {
+		if d == Disabled {
+			continue
+		}
+		if d < 0 {
+			return errors.New(ERROR_NEGATIVE_DURATION)
+		}
+		if d <= last {
+			return errors.New(ERROR_THRESHOLD_ORDER)
+		}
+		last = d
+	}
+	if cfg.SuperLoop && !cfg.Ring && !cfg.EdgeFlag {
+		return errors.New(ERROR_SUPERLOOP_NEEDS_RING)
+	}
+	if cfg.Max != 0 && cfg.Max <= last {
+		return errors.New(ERROR_THRESHOLD_ORDER)
+	}
+	if cfg.ShortTicks == 0 {
+		return nil
+	}
+	// unset long tiers are simply never recognized, but those which are set must be in order
+	if cfg.LongTicks != 0 && cfg.LongTicks <= cfg.ShortTicks {
+		return errors.New(ERROR_TICK_THRESHOLD_ORDER)
+	}
+	if cfg.ExtraLongTicks != 0 && (cfg.ExtraLongTicks <= cfg.ShortTicks || cfg.ExtraLongTicks <= cfg.LongTicks) {
+		return errors.New(ERROR_TICK_THRESHOLD_ORDER)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package bouncer
+
+// handleVibrationEdge counts an edge from a vibration or tilt sensor, opening a counting window with the first.
+// Every bounce is an edge like any other here; it's their rate that matters
+func (b *bouncer) handleVibrationEdge(e Edge) {
+	if b.ticks == 0 { // open a window
+		b.ticks = 1
+		b.btnDown = e.Time
+		b.edges = 0
+	}
+	b.edges += 1
+	if b.edges < b.vibrationEdges {
+		return
+	}
+	b.ticks = 0 // close the window; the next edge opens another
+	b.publish(Vibration)
+}
+
+// handleVibrationTick closes a counting window which has expired without reaching the threshold
+func (b *bouncer) handleVibrationTick() {
+	if b.ticks == 0 {
+		return
+	}
+	b.ticks += 1
+	if clockSince(b.btnDown) >= b.vibrationWindow {
+		b.ticks = 0
+	}
+}
@@ -0,0 +1,48 @@
+package bouncer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// WaitForPress blocks until the bouncer publishes any event, returning it and true, or returns false once
+// timeout has passed. It's for simple sequential flows ("press any key within 10s to enter setup") which
+// would otherwise need their own goroutine, channel & select; RecognizeAndPublish must be running
+func (b *bouncer) WaitForPress(timeout time.Duration) (PressLength, bool) {
+	return b.WaitFor(timeout)
+}
+
+// WaitFor is WaitForPress for only the given PressLengths; other events are ignored (though still published)
+func (b *bouncer) WaitFor(timeout time.Duration, lengths ...PressLength) (PressLength, bool) {
+	select {
+	case <-b.waitCh: // discard anything left over from an earlier wait
+	default:
+	}
+	atomic.StoreUint32(&b.waiting, 1)
+	defer atomic.StoreUint32(&b.waiting, 0)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case p := <-b.waitCh:
+			if wanted(p, lengths) {
+				return p, true
+			}
+		case <-timer.C:
+			return Bounce, false
+		}
+	}
+}
+
+// wanted reports whether p is among lengths, or lengths is empty
+func wanted(p PressLength, lengths []PressLength) bool {
+	if len(lengths) == 0 {
+		return true
+	}
+	for _, l := range lengths {
+		if l == p {
+			return true
+		}
+	}
+	return false
+}
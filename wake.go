@@ -0,0 +1,22 @@
+package bouncer
+
+import "sync/atomic"
+
+// ConfigureWake arms the bouncer's pin(s) as a deep-sleep wake source on a button press,
+// where the target supports it; elsewhere it returns ERROR_WAKE_UNSUPPORTED
+func (b *bouncer) ConfigureWake() error {
+	for _, p := range b.pins {
+		if err := setWakeSource(p, b.activeHigh); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Wake should be called after waking from sleep. The edge that woke the device is usually lost,
+// so on the next tick the pin is resampled and, if the button is being held, a press begins as if
+// the 'down' edge had been seen
+func (b *bouncer) Wake() {
+	atomic.StoreUint32(&b.rearm, 1)
+	b.setListening(true)
+}
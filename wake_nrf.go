@@ -0,0 +1,23 @@
+//go:build nrf
+
+package bouncer
+
+import (
+	"device/nrf"
+	"errors"
+	"machine"
+)
+
+// setWakeSource enables the GPIO SENSE mechanism on the pin, which wakes the chip from System OFF
+// when the pin is driven to its pressed level
+func setWakeSource(p machine.Pin, activeHigh bool) error {
+	if p >= 32 {
+		return errors.New(ERROR_WAKE_UNSUPPORTED)
+	}
+	sense := uint32(nrf.GPIO_PIN_CNF_SENSE_Low)
+	if activeHigh {
+		sense = nrf.GPIO_PIN_CNF_SENSE_High
+	}
+	nrf.P0.PIN_CNF[p].ReplaceBits(sense, nrf.GPIO_PIN_CNF_SENSE_Msk>>nrf.GPIO_PIN_CNF_SENSE_Pos, nrf.GPIO_PIN_CNF_SENSE_Pos)
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !nrf
+
+package bouncer
+
+import (
+	"errors"
+	"machine"
+)
+
+// setWakeSource is unsupported on this target
+func setWakeSource(p machine.Pin, activeHigh bool) error {
+	return errors.New(ERROR_WAKE_UNSUPPORTED)
+}
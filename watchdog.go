@@ -0,0 +1,13 @@
+package bouncer
+
+// watchdogKick is called by the relay after each round of ticks every listening subscriber has taken
+var watchdogKick func()
+
+// FeedWatchdog has the tick relay call kick (e.g. machine.Watchdog.Update) each time it has delivered a tick to
+// every listening subscriber. A round in which any subscriber still hadn't taken its previous tick (and so lost
+// this one) isn't fed, so feeding stops by itself if the tick stream stalls or a recognizer wedges and stops
+// taking ticks, and an input subsystem fault resets the device instead of leaving it unresponsive.
+// Don't combine this with stopping the systick from an OnIdle hook
+func FeedWatchdog(kick func()) {
+	watchdogKick = kick
+}
@@ -0,0 +1,35 @@
+package bouncer
+
+import "time"
+
+// wearWindow is how many recent presses' bounce is averaged when watching for wear
+const wearWindow = 8
+
+// bounce notes an edge the recognizer is ignoring as bounce
+func (b *bouncer) bounce(e Edge) {
+	b.bounceEnd = e.Time
+	if b.bounces < 255 {
+		b.bounces++
+	}
+}
+
+// wearTrend adds a press's bounce to the rolling window, and reports whether the average has just passed
+// DegradedBounce. Nothing is reported until the window has filled
+func (b *bouncer) wearTrend(bounce time.Duration) bool {
+	b.wear[b.wearAt] = bounce
+	b.wearAt = (b.wearAt + 1) % wearWindow
+	if b.wearN < wearWindow {
+		b.wearN++
+		return false
+	}
+	var sum time.Duration
+	for _, d := range b.wear {
+		sum += d
+	}
+	worn := sum/wearWindow > b.degradedBounce
+	if worn == b.degraded {
+		return false
+	}
+	b.degraded = worn
+	return worn
+}